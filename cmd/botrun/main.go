@@ -0,0 +1,107 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/staylor11x/spider-solitaire/internal/bot"
+	"github.com/staylor11x/spider-solitaire/internal/deck"
+)
+
+func main() {
+	strategy := flag.String("strategy", "greedy", "strategy to play with: "+strings.Join(bot.Names(), ", "))
+	games := flag.Int("games", 100, "deals to play per suit count")
+	suitsFlag := flag.String("suits", "1,2,4", "comma-separated suit counts to play")
+	seed := flag.Uint64("seed", 1, "first deal number; deals use seed, seed+1, ...")
+	workers := flag.Int("workers", runtime.NumCPU(), "games played in parallel")
+	maxActions := flag.Int("max-actions", bot.DefaultMaxActions, "give up on a game after this many actions")
+	flag.Parse()
+
+	factory, err := bot.Lookup(*strategy)
+	if err != nil {
+		log.Fatal(err)
+	}
+	suits, err := parseSuits(*suitsFlag)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	start := time.Now()
+	reports := bot.Run(bot.Config{
+		Strategy:   factory,
+		Suits:      suits,
+		Games:      *games,
+		FirstSeed:  *seed,
+		Workers:    *workers,
+		MaxActions: *maxActions,
+	})
+
+	fmt.Printf("strategy %s, %d games per suit count from seed %d (%s)\n\n",
+		*strategy, *games, *seed, time.Since(start).Round(time.Millisecond))
+	printSummary(reports)
+	for _, r := range reports {
+		printFailures(r)
+	}
+
+	// engine errors are rule bugs: list the deals so they can be replayed
+	failed := false
+	for _, r := range reports {
+		for _, e := range r.Errors {
+			failed = true
+			fmt.Fprintf(os.Stderr, "engine error: %d-suit seed %d: %v\n", e.Suits, e.Seed, e.Err)
+		}
+	}
+	if failed {
+		os.Exit(1)
+	}
+}
+
+func parseSuits(s string) ([]deck.SuitCount, error) {
+	var out []deck.SuitCount
+	for _, part := range strings.Split(s, ",") {
+		n, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil || !deck.SuitCount(n).Valid() {
+			return nil, fmt.Errorf("invalid -suits entry %q: must be 1, 2 or 4", part)
+		}
+		if slices.Contains(out, deck.SuitCount(n)) {
+			return nil, fmt.Errorf("duplicate -suits entry %q", part)
+		}
+		out = append(out, deck.SuitCount(n))
+	}
+	return out, nil
+}
+
+func printSummary(reports []bot.Report) {
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "suits\tgames\twins\twin rate\tavg runs\tavg moves\tstuck\tresigned\tmove cap\t")
+	for _, r := range reports {
+		fmt.Fprintf(tw, "%d\t%d\t%d\t%.1f%%\t%.2f\t%.1f\t%d\t%d\t%d\t\n",
+			r.Suits, r.Games, r.Wins, 100*r.WinRate(), r.AvgCompleted(), r.AvgMoves(),
+			r.Outcomes[bot.Stuck], r.Outcomes[bot.Resigned], r.Outcomes[bot.Capped])
+	}
+	tw.Flush()
+}
+
+// printFailures shows where unwon games stopped, by runs completed and rows dealt
+func printFailures(r bot.Report) {
+	if r.Games == r.Wins {
+		return
+	}
+	fmt.Printf("\n%d-suit failures by runs completed:", r.Suits)
+	for runs, n := range r.FailedAtCompleted {
+		fmt.Printf(" %d:%d", runs, n)
+	}
+	fmt.Printf("\n%d-suit failures by rows dealt:     ", r.Suits)
+	for deals, n := range r.FailedAtDeals {
+		fmt.Printf(" %d:%d", deals, n)
+	}
+	fmt.Println()
+}
@@ -1,27 +1,144 @@
 package main
 
 import (
+	"encoding/json"
 	"flag"
 	"fmt"
 	"log"
+	"os"
+	"time"
 
+	"github.com/staylor11x/spider-solitaire/internal/cli"
+	"github.com/staylor11x/spider-solitaire/internal/daily"
 	"github.com/staylor11x/spider-solitaire/internal/deck"
 	"github.com/staylor11x/spider-solitaire/internal/game"
 	"github.com/staylor11x/spider-solitaire/internal/printer"
+	"github.com/staylor11x/spider-solitaire/internal/protocol"
+	"github.com/staylor11x/spider-solitaire/internal/record"
+	"github.com/staylor11x/spider-solitaire/internal/storage"
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "stats" {
+		if err := runStats(os.Args[2:], os.Stdout); err != nil {
+			log.Fatalf("stats: %v", err)
+		}
+		return
+	}
+
 	ascii := flag.Bool("ascii", false, "use ASCII suits (S/H/D/C) instead of Unicode")
+	color := flag.Bool("color", isTerminal(os.Stdout) && os.Getenv("NO_COLOR") == "", "color red suits (default on for terminals unless NO_COLOR is set)")
+	compact := flag.Bool("compact", false, "print one line per pile instead of columns")
+	jsonOut := flag.Bool("json", false, "print the starting position once as JSON (see game.ViewSchemaVersion) and exit without playing; use -protocol to play over JSON")
+	proto := flag.Bool("protocol", false, "speak the line-based bot protocol on stdin/stdout (see internal/protocol)")
+	suits := flag.Int("suits", 1, "number of suits: 1, 2 or 4")
+	seed := flag.Uint64("seed", 0, "deal number to replay (random when omitted)")
+	dailyDeal := flag.Bool("daily", false, "play today's daily challenge at the chosen -suits")
+	load := flag.String("load", "", "resume the game saved at this path instead of dealing")
+	save := flag.String("save", "", "save the game to this path on exit (also the default for save and load)")
+	replay := flag.String("replay", "", "replay the game record at this path and show where it ends")
 	flag.Parse()
 
-	g, err := game.DealInitialGame(deck.OneSuit)
+	// flags given on the command line, as opposed to left at their defaults
+	set := map[string]bool{}
+	flag.Visit(func(f *flag.Flag) { set[f.Name] = true })
+
+	suitCount := deck.SuitCount(*suits)
+	if !suitCount.Valid() {
+		log.Fatalf("invalid -suits %d: must be 1, 2 or 4", *suits)
+	}
+	switch {
+	case *dailyDeal && set["seed"]:
+		log.Fatal("-daily and -seed can't be used together")
+	case *dailyDeal:
+		*seed = daily.Seed(time.Now(), suitCount)
+	case !set["seed"]:
+		*seed = deck.RandomSeed()
+	}
+
+	var g *game.GameState
+	var err error
+	loaded := false // the game came from a save file
+	switch {
+	case *replay != "":
+		rec, err := storage.LoadRecord(*replay)
+		if err != nil {
+			log.Fatalf("replay failed: %v", err)
+		}
+		g, err = record.Replay(rec)
+		if err != nil {
+			if g == nil {
+				log.Fatalf("replay failed: %v", err)
+			}
+			// show the position the bad move was made from (on stderr so -json output stays clean)
+			fmt.Fprintln(os.Stderr, err)
+		}
+	case *load != "":
+		g, err = storage.LoadGame(*load)
+		if err != nil {
+			log.Fatalf("load failed: %v", err)
+		}
+		loaded = true
+	default:
+		g, err = game.DealSeededGame(suitCount, *seed)
+		if err != nil {
+			log.Fatalf("deal failed: %v", err)
+		}
+		if *dailyDeal && !*jsonOut {
+			fmt.Printf("Daily challenge for %s\n", daily.Key(time.Now()))
+		}
+	}
+
+	// machine-readable snapshot of the starting position, e.g. for -replay or -load
+	if *jsonOut {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(g.View()); err != nil {
+			log.Fatalf("encode json: %v", err)
+		}
+		return
+	}
+
+	if *proto {
+		if err := protocol.Serve(os.Stdin, os.Stdout, g); err != nil {
+			log.Fatalf("protocol: %v", err)
+		}
+		return
+	}
+
+	// finished and abandoned games go in the statistics; without a config dir they aren't kept
+	statsPath, err := storage.StatsPath()
 	if err != nil {
-		log.Fatalf("deal failed: %v", err)
+		log.Printf("statistics disabled: %v", err)
 	}
+	dailyPath, _ := storage.DailyPath() // fails exactly when StatsPath does
 
-	view := g.View()
-	out := printer.Render(view, printer.Options{
-		UnicodeSuits: !*ascii,
+	// play interactively until quit, or run a script piped in on stdin
+	session := cli.NewSession(g, os.Stdout, cli.Options{
+		Render:    printer.Options{UnicodeSuits: !*ascii, Color: *color, Compact: *compact},
+		SavePath:  *save,
+		StatsPath: statsPath,
+		DailyPath: dailyPath,
+		Loaded:    loaded,
 	})
-	fmt.Print(out)
+	if err := session.Run(os.Stdin); err != nil {
+		log.Fatalf("read commands: %v", err)
+	}
+
+	// a game left saved, by -save or the save command, or loaded and not played since,
+	// can be resumed, so it isn't abandoned
+	switch {
+	case *save != "":
+		if err := storage.SaveGame(*save, session.Game()); err != nil {
+			log.Fatalf("save failed: %v", err)
+		}
+	case !session.Saved():
+		session.Abandon()
+	}
+}
+
+// isTerminal reports whether f is an interactive terminal rather than a pipe or file
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
 }
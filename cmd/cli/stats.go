@@ -0,0 +1,87 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"github.com/staylor11x/spider-solitaire/internal/deck"
+	"github.com/staylor11x/spider-solitaire/internal/stats"
+	"github.com/staylor11x/spider-solitaire/internal/storage"
+)
+
+// runStats is the stats subcommand: it prints the player's statistics per difficulty,
+// or exports or resets them
+func runStats(args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("stats", flag.ExitOnError)
+	suits := fs.Int("suits", 0, "only show games with this many suits: 1, 2 or 4 (all when omitted)")
+	csvPath := fs.String("csv", "", "export every logged game as CSV to this path (- for stdout)")
+	reset := fs.Bool("reset", false, "delete all statistics")
+	fs.Parse(args)
+
+	if *suits != 0 && !deck.SuitCount(*suits).Valid() {
+		return fmt.Errorf("invalid -suits %d: must be 1, 2 or 4", *suits)
+	}
+	path, err := storage.StatsPath()
+	if err != nil {
+		return err
+	}
+	l, err := storage.LoadStats(path)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case *reset:
+		l.Reset()
+		if err := storage.SaveStats(path, l); err != nil {
+			return err
+		}
+		fmt.Fprintln(out, "statistics reset")
+		return nil
+	case *csvPath == "-":
+		return l.WriteCSV(out)
+	case *csvPath != "":
+		if err := storage.ExportStatsCSV(*csvPath, l); err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "exported %d games to %s\n", len(l.Games), *csvPath)
+		return nil
+	}
+
+	rows := []deck.SuitCount{deck.OneSuit, deck.TwoSuits, deck.FourSuits, 0}
+	if *suits != 0 {
+		rows = []deck.SuitCount{deck.SuitCount(*suits)}
+	}
+	tw := tabwriter.NewWriter(out, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "suits\tplayed\twon\twin %\tstreak\tbest streak\tfastest win\tbest score\t")
+	for _, sc := range rows {
+		s := l.Summary(sc)
+		label := "all"
+		if sc != 0 {
+			label = fmt.Sprint(int(sc))
+		}
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%.0f\t%d\t%d\t%s\t%s\t\n", label, s.Played, s.Won, s.WinRate(),
+			s.CurrentStreak, s.BestStreak, formatDuration(s.FastestWin), formatScore(s))
+	}
+	return tw.Flush()
+}
+
+// formatDuration prints a play time as m:ss, or - before any game is won
+func formatDuration(d time.Duration) string {
+	if d == 0 {
+		return "-"
+	}
+	secs := int(d.Round(time.Second) / time.Second)
+	return fmt.Sprintf("%d:%02d", secs/60, secs%60)
+}
+
+// formatScore prints the best score, or - before any game is won
+func formatScore(s stats.Summary) string {
+	if s.Won == 0 {
+		return "-"
+	}
+	return fmt.Sprint(s.BestScore)
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"log"
 
@@ -15,20 +16,51 @@ var (
 )
 
 func main() {
+	suits := flag.Int("suits", 1, "number of suits: 1, 2 or 4 (skips the menu)")
+	seed := flag.Uint64("seed", 0, "deal number to replay, random when omitted (skips the menu)")
+	resume := flag.Bool("continue", false, "resume the saved game instead of showing the menu")
+	flag.Parse()
+
+	// flags given on the command line, as opposed to left at their defaults
+	set := map[string]bool{}
+	flag.Visit(func(f *flag.Flag) { set[f.Name] = true })
 
 	log.Printf("Spider Solitaire %s (built %s)", Version, BuildTime)
 
+	suitCount := deck.SuitCount(*suits)
+	if !suitCount.Valid() {
+		log.Fatalf("invalid -suits %d: must be 1, 2 or 4", *suits)
+	}
+	if !set["seed"] {
+		*seed = deck.RandomSeed()
+	}
+
 	// set window properties
 	ebiten.SetWindowTitle(fmt.Sprintf("Spider Solitaire %s", Version))
 	ebiten.SetWindowSize(1280, 720)
 	ebiten.SetWindowTitle("Spider Solitaire")
 	ebiten.SetWindowResizingMode(ebiten.WindowResizingModeEnabled)
+	ebiten.SetWindowClosingHandled(true) // lets the game autosave before exiting
 
 	// create the game instance
-	game := spiderui.NewGame(deck.OneSuit)
+	game := newGame(suitCount, *seed, *resume, set["suits"] || set["seed"])
 
 	// run the game loop, this blocks until the window closes or an error occurs
 	if err := ebiten.RunGame(game); err != nil {
 		log.Fatalf("game loop failed: %v", err)
 	}
 }
+
+// newGame opens the main menu unless the flags ask for a particular game: it resumes
+// the saved game when asked to (and one exists), otherwise deals the requested one. A save
+// that can't be resumed opens the menu saying why.
+// dealChosen reports whether -suits or -seed was given.
+func newGame(suitCount deck.SuitCount, seed uint64, resume, dealChosen bool) *spiderui.Game {
+	if !resume && !dealChosen {
+		return spiderui.NewMenu()
+	}
+	if resume {
+		return spiderui.NewContinued(suitCount, seed)
+	}
+	return spiderui.NewGame(suitCount, seed)
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/staylor11x/spider-solitaire/internal/server"
+)
+
+func main() {
+	addr := flag.String("addr", "localhost:8080", "address to listen on")
+	maxSessions := flag.Int("max-sessions", server.DefaultMaxSessions, "most games hosted at once")
+	idle := flag.Duration("idle", server.DefaultIdleTimeout, "end games untouched for this long")
+	watchGrace := flag.Duration("watch-grace", server.DefaultWatchGrace, "extra idle time for games with an open event stream")
+	flag.Parse()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	m := server.NewManager(server.Config{MaxSessions: *maxSessions, IdleTimeout: *idle, WatchGrace: *watchGrace})
+	go m.RunExpiry(ctx, time.Minute)
+
+	srv := &http.Server{
+		Addr:              *addr,
+		Handler:           server.NewHandler(m),
+		ReadHeaderTimeout: 10 * time.Second,
+		BaseContext:       func(net.Listener) context.Context { return ctx }, // ends event streams on shutdown
+	}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := srv.Shutdown(shutdownCtx); err != nil {
+			log.Printf("shutdown: %v", err)
+		}
+	}()
+
+	log.Printf("Spider Solitaire server listening on http://%s", *addr)
+	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Fatalf("server: %v", err)
+	}
+}
@@ -0,0 +1,230 @@
+package bot
+
+import (
+	"cmp"
+	"runtime"
+	"slices"
+	"sync"
+
+	"github.com/staylor11x/spider-solitaire/internal/deck"
+	"github.com/staylor11x/spider-solitaire/internal/game"
+)
+
+// DefaultMaxActions stops a game that neither ends nor resigns
+const DefaultMaxActions = 2000
+
+// Outcome is how a single game ended
+type Outcome int
+
+const (
+	Won      Outcome = iota
+	Stuck            // the engine declared the game lost
+	Resigned         // the strategy gave up
+	Capped           // hit MaxActions
+	Errored          // the engine rejected a move it had listed as legal
+)
+
+func (o Outcome) String() string {
+	switch o {
+	case Won:
+		return "won"
+	case Stuck:
+		return "stuck"
+	case Resigned:
+		return "resigned"
+	case Capped:
+		return "move cap"
+	case Errored:
+		return "engine error"
+	default:
+		return "unknown"
+	}
+}
+
+// GameResult describes one played game
+type GameResult struct {
+	Suits     deck.SuitCount
+	Seed      uint64
+	Outcome   Outcome
+	Completed int // runs completed
+	Moves     int // sequence moves made
+	Deals     int // rows dealt, i.e. how far into the stock the game got
+	Err       error
+}
+
+// Play runs one game to the end with the given strategy
+func Play(s Strategy, suits deck.SuitCount, seed uint64, maxActions int) GameResult {
+	if maxActions <= 0 {
+		maxActions = DefaultMaxActions
+	}
+	res := GameResult{Suits: suits, Seed: seed}
+	g, err := game.DealSeededGame(suits, seed)
+	if err != nil {
+		res.Outcome, res.Err = Errored, err
+		return res
+	}
+
+	res.Outcome = Capped
+	for range maxActions {
+		if g.Won || g.Lost {
+			break
+		}
+		legal := g.LegalMoves()
+		if len(legal) == 0 {
+			res.Outcome = Stuck
+			break
+		}
+		m, ok := s.Next(g.View().Masked(), legal)
+		if !ok {
+			res.Outcome = Resigned
+			break
+		}
+		if err := g.Apply(m); err != nil {
+			res.Outcome, res.Err = Errored, err
+			break
+		}
+	}
+	switch {
+	case g.Won:
+		res.Outcome = Won
+	case g.Lost:
+		res.Outcome = Stuck
+	}
+
+	res.Completed = len(g.Completed)
+	res.Moves = g.Moves
+	res.Deals = g.Deals
+	return res
+}
+
+// Config describes a batch of games
+type Config struct {
+	Strategy   Factory
+	Suits      []deck.SuitCount // one report each, in order; repeats are played once
+	Games      int              // deals played per suit count
+	FirstSeed  uint64           // deals use seeds FirstSeed, FirstSeed+1, ...
+	Workers    int              // goroutines; runtime.NumCPU() when zero
+	MaxActions int              // per game; DefaultMaxActions when zero
+}
+
+// Run plays the batch in parallel. Results are the same for any number of workers.
+func Run(cfg Config) []Report {
+	workers := cfg.Workers
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	var suitCounts []deck.SuitCount
+	for _, suits := range cfg.Suits {
+		if !slices.Contains(suitCounts, suits) {
+			suitCounts = append(suitCounts, suits)
+		}
+	}
+
+	type job struct {
+		suits deck.SuitCount
+		seed  uint64
+	}
+	jobs := make(chan job)
+	results := make(chan GameResult)
+
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				results <- Play(cfg.Strategy(j.seed), j.suits, j.seed, cfg.MaxActions)
+			}
+		}()
+	}
+	go func() {
+		for _, suits := range suitCounts {
+			for i := range cfg.Games {
+				jobs <- job{suits: suits, seed: cfg.FirstSeed + uint64(i)}
+			}
+		}
+		close(jobs)
+		wg.Wait()
+		close(results)
+	}()
+
+	reports := make([]Report, len(suitCounts))
+	index := make(map[deck.SuitCount]int, len(suitCounts))
+	for i, suits := range suitCounts {
+		reports[i] = Report{Suits: suits}
+		index[suits] = i
+	}
+	for res := range results {
+		reports[index[res.Suits]].add(res)
+	}
+	for i := range reports {
+		// results arrive in whatever order the workers finish
+		slices.SortFunc(reports[i].Errors, func(a, b GameResult) int { return cmp.Compare(a.Seed, b.Seed) })
+	}
+	return reports
+}
+
+// Report aggregates the games played at one suit count
+type Report struct {
+	Suits          deck.SuitCount
+	Games          int
+	Wins           int
+	TotalCompleted int
+	TotalMoves     int
+	Outcomes       map[Outcome]int
+
+	// Failure points: for games that weren't won, how many runs were done and
+	// how many rows had been dealt when play stopped
+	FailedAtCompleted [game.TotalRunsToWin]int
+	FailedAtDeals     [maxDeals + 1]int
+
+	Errors []GameResult // games the engine rejected a legal move in, for regression hunting
+}
+
+// maxDeals is how many rows the stock holds
+const maxDeals = (game.TotalSpiderCards - game.FirstPileCount*game.FirstPileCards -
+	(game.TableauPiles-game.FirstPileCount)*game.RestPileCards) / game.TableauPiles
+
+func (r *Report) add(res GameResult) {
+	if r.Outcomes == nil {
+		r.Outcomes = make(map[Outcome]int)
+	}
+	r.Games++
+	r.Outcomes[res.Outcome]++
+	r.TotalCompleted += res.Completed
+	r.TotalMoves += res.Moves
+
+	switch res.Outcome {
+	case Won:
+		r.Wins++
+		return
+	case Errored:
+		r.Errors = append(r.Errors, res)
+	}
+	r.FailedAtCompleted[min(res.Completed, game.TotalRunsToWin-1)]++
+	r.FailedAtDeals[min(res.Deals, maxDeals)]++
+}
+
+// WinRate is the fraction of games won
+func (r Report) WinRate() float64 {
+	if r.Games == 0 {
+		return 0
+	}
+	return float64(r.Wins) / float64(r.Games)
+}
+
+// AvgCompleted is the mean number of runs completed per game
+func (r Report) AvgCompleted() float64 {
+	if r.Games == 0 {
+		return 0
+	}
+	return float64(r.TotalCompleted) / float64(r.Games)
+}
+
+// AvgMoves is the mean number of sequence moves per game
+func (r Report) AvgMoves() float64 {
+	if r.Games == 0 {
+		return 0
+	}
+	return float64(r.TotalMoves) / float64(r.Games)
+}
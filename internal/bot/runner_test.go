@@ -0,0 +1,74 @@
+package bot
+
+import (
+	"testing"
+
+	"github.com/staylor11x/spider-solitaire/internal/deck"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlay_GreedyCanWin(t *testing.T) {
+	// seed 1 is a greedy win at one suit; pinned so rule regressions show up here
+	res := Play(NewGreedy(1), deck.OneSuit, 1, 0)
+	require.NoError(t, res.Err)
+	assert.Equal(t, Won, res.Outcome)
+	assert.Equal(t, 8, res.Completed)
+	assert.Positive(t, res.Moves)
+}
+
+func TestPlay_RespectsActionCap(t *testing.T) {
+	res := Play(NewRandom(1), deck.FourSuits, 1, 10)
+	assert.Equal(t, Capped, res.Outcome)
+	assert.LessOrEqual(t, res.Moves+res.Deals, 10)
+}
+
+func TestRun_SameResultsForAnyWorkerCount(t *testing.T) {
+	cfg := Config{
+		Strategy:  NewGreedy,
+		Suits:     []deck.SuitCount{deck.OneSuit, deck.TwoSuits},
+		Games:     20,
+		FirstSeed: 100,
+	}
+
+	cfg.Workers = 1
+	serial := Run(cfg)
+	cfg.Workers = 4
+	parallel := Run(cfg)
+	assert.Equal(t, serial, parallel)
+
+	require.Len(t, serial, 2)
+	for _, r := range serial {
+		assert.Equal(t, 20, r.Games)
+		assert.Empty(t, r.Errors, "the engine must accept every move it lists as legal")
+
+		failed := 0
+		for _, n := range r.FailedAtCompleted {
+			failed += n
+		}
+		assert.Equal(t, r.Games-r.Wins, failed, "every lost game has a failure point")
+	}
+	assert.Equal(t, deck.OneSuit, serial[0].Suits)
+	assert.Greater(t, serial[0].WinRate(), serial[1].WinRate(), "one suit should be easier than two")
+}
+
+func TestRun_PlaysRepeatedSuitCountsOnce(t *testing.T) {
+	reports := Run(Config{
+		Strategy: NewGreedy,
+		Suits:    []deck.SuitCount{deck.OneSuit, deck.TwoSuits, deck.OneSuit},
+		Games:    3,
+	})
+
+	require.Len(t, reports, 2)
+	assert.Equal(t, deck.OneSuit, reports[0].Suits)
+	assert.Equal(t, 3, reports[0].Games)
+	assert.Equal(t, deck.TwoSuits, reports[1].Suits)
+	assert.Equal(t, 3, reports[1].Games)
+}
+
+func TestReport_EmptyAverages(t *testing.T) {
+	var r Report
+	assert.Zero(t, r.WinRate())
+	assert.Zero(t, r.AvgCompleted())
+	assert.Zero(t, r.AvgMoves())
+}
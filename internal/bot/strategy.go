@@ -0,0 +1,105 @@
+// Package bot plays spider headlessly: pluggable strategies choose moves from what a
+// player can see, and the runner plays many seeded deals in parallel and reports how
+// they went. It is used to benchmark heuristics and to exercise the engine at scale.
+package bot
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/staylor11x/spider-solitaire/internal/deck"
+	"github.com/staylor11x/spider-solitaire/internal/game"
+)
+
+// Strategy picks the next action. The view is masked (face-down cards carry no rank
+// or suit) and legal is never empty. Returning ok=false resigns the game.
+type Strategy interface {
+	Next(view game.GameViewDTO, legal []game.Move) (m game.Move, ok bool)
+}
+
+// Factory makes a fresh strategy for one game. The seed lets randomized strategies
+// replay the same game the same way.
+type Factory func(seed uint64) Strategy
+
+// strategies is the registry used by Lookup and Names
+var strategies = map[string]Factory{
+	"random": NewRandom,
+	"greedy": NewGreedy,
+}
+
+// Lookup returns the factory registered under name
+func Lookup(name string) (Factory, error) {
+	f, ok := strategies[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown strategy %q (have %s)", name, strings.Join(Names(), ", "))
+	}
+	return f, nil
+}
+
+// Names lists the registered strategies in alphabetical order
+func Names() []string {
+	names := make([]string, 0, len(strategies))
+	for name := range strategies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Random plays a uniformly random legal move. It is the baseline other strategies must beat.
+type Random struct {
+	rng *deck.RNG
+}
+
+// NewRandom creates a random player seeded for reproducible games
+func NewRandom(seed uint64) Strategy {
+	return &Random{rng: deck.NewRNG(seed)}
+}
+
+func (r *Random) Next(view game.GameViewDTO, legal []game.Move) (game.Move, bool) {
+	return legal[r.rng.Intn(len(legal))], true
+}
+
+// Greedy plays the best-ranked hint (see game.RankMoves). To avoid shuffling cards back
+// and forth forever it falls back to the next hint each time a position repeats, and
+// resigns once every hint for a position has been tried.
+type Greedy struct {
+	visits map[string]int
+}
+
+// NewGreedy creates a greedy player; the seed is unused as it is deterministic
+func NewGreedy(uint64) Strategy {
+	return &Greedy{visits: make(map[string]int)}
+}
+
+func (g *Greedy) Next(view game.GameViewDTO, legal []game.Move) (game.Move, bool) {
+	hints := game.RankMoves(view, legal)
+	if len(hints) == 0 {
+		return game.Move{}, false // only pointless shuffles are left
+	}
+	key := viewKey(view)
+	n := g.visits[key]
+	g.visits[key] = n + 1
+	if n >= len(hints) {
+		return game.Move{}, false
+	}
+	return hints[n].Move, true
+}
+
+// viewKey identifies a visible position
+func viewKey(view game.GameViewDTO) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d/%d|", view.StockCount, view.CompletedCount)
+	for _, pile := range view.Tableau {
+		for _, c := range pile.Cards {
+			if c.FaceUp {
+				fmt.Fprintf(&b, "%d.%d,", c.Rank, c.Suit)
+			} else {
+				b.WriteString("#,")
+			}
+		}
+		b.WriteByte('|')
+	}
+	return b.String()
+}
@@ -0,0 +1,77 @@
+package bot
+
+import (
+	"testing"
+
+	"github.com/staylor11x/spider-solitaire/internal/deck"
+	"github.com/staylor11x/spider-solitaire/internal/game"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLookup(t *testing.T) {
+	assert.Equal(t, []string{"greedy", "random"}, Names())
+
+	for _, name := range Names() {
+		f, err := Lookup(name)
+		require.NoError(t, err)
+		assert.NotNil(t, f(1))
+	}
+
+	_, err := Lookup("oracle")
+	assert.ErrorContains(t, err, "greedy, random")
+}
+
+func TestStrategies_PickLegalMoves(t *testing.T) {
+	for _, name := range Names() {
+		t.Run(name, func(t *testing.T) {
+			f, err := Lookup(name)
+			require.NoError(t, err)
+			s := f(5)
+
+			g, err := game.DealSeededGame(deck.TwoSuits, 5)
+			require.NoError(t, err)
+			for range 50 {
+				legal := g.LegalMoves()
+				m, ok := s.Next(g.View().Masked(), legal)
+				if !ok {
+					break
+				}
+				assert.Contains(t, legal, m)
+				require.NoError(t, g.Apply(m))
+			}
+		})
+	}
+}
+
+func TestRandom_IsReproducible(t *testing.T) {
+	g, err := game.DealSeededGame(deck.OneSuit, 1)
+	require.NoError(t, err)
+	legal := g.LegalMoves()
+	view := g.View().Masked()
+
+	a, b := NewRandom(9), NewRandom(9)
+	for range 20 {
+		ma, _ := a.Next(view, legal)
+		mb, _ := b.Next(view, legal)
+		assert.Equal(t, ma, mb)
+	}
+}
+
+func TestGreedy_PrefersBestHintAndAvoidsRepeats(t *testing.T) {
+	g, err := game.DealSeededGame(deck.OneSuit, 3)
+	require.NoError(t, err)
+	legal := g.LegalMoves()
+	view := g.View().Masked()
+	hints := game.RankMoves(view, legal)
+	require.NotEmpty(t, hints)
+
+	s := NewGreedy(0)
+	for i, h := range hints {
+		m, ok := s.Next(view, legal)
+		require.True(t, ok)
+		assert.Equal(t, h.Move, m, "visit %d of the same position should try hint %d", i+1, i+1)
+	}
+	_, ok := s.Next(view, legal)
+	assert.False(t, ok, "greedy resigns once every hint has been tried")
+}
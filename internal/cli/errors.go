@@ -0,0 +1,52 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/staylor11x/spider-solitaire/internal/game"
+	"github.com/staylor11x/spider-solitaire/internal/record"
+)
+
+// friendlyErrors words the engine's rule errors for players rather than programmers
+var friendlyErrors = []struct {
+	err error
+	msg string
+}{
+	{game.ErrDestinationNotAccepting, "that pile can't take those cards, the top card must be one rank higher"},
+	{game.ErrInvalidSequence, "only a same-suit run in descending order can move together"},
+	{game.ErrSamePileMove, "pick a different destination pile"},
+	{game.ErrInvalidSourceIndex, "there is no such source pile, piles are numbered 0-9"},
+	{game.ErrInvalidDestinationIndex, "there is no such destination pile, piles are numbered 0-9"},
+	{game.ErrInvalidStartIndex, "that pile has no card at that position"},
+	{game.ErrNoCardsToMove, "there are no cards there to move"},
+	{game.ErrInsufficientStock, "the stock is empty, there is nothing left to deal"},
+	{game.ErrNoHistory, "there is nothing to undo"},
+	{game.ErrNoRedo, "there is nothing to redo"},
+	{game.ErrCannotAutoComplete, "auto needs an empty stock, every card face up and a way to finish"},
+	{game.ErrInvalidSave, "that file is not a valid saved game"},
+	{record.ErrGameInProgress, "a game loaded part way through can't be exported, its earlier moves weren't recorded"},
+	{os.ErrNotExist, "no saved game found there"},
+}
+
+// friendlyError turns an error into a message for the player
+func friendlyError(err error) string {
+	var faceDown game.CardFaceDownError
+	if errors.As(err, &faceDown) {
+		return fmt.Sprintf("the card at position %d is still face down", faceDown.Index)
+	}
+	var version game.SaveVersionError
+	if errors.As(err, &version) {
+		if version.Newer() {
+			return "that save was made by a newer version of the game"
+		}
+		return "that file is not a valid saved game"
+	}
+	for _, f := range friendlyErrors {
+		if errors.Is(err, f.err) {
+			return f.msg
+		}
+	}
+	return err.Error()
+}
@@ -0,0 +1,444 @@
+// Package cli is the interactive text front end: a read-eval-print loop over the
+// engine that renders through printer.Render after every action. It reads commands
+// from any io.Reader, so games can be played at a terminal or scripted from a file.
+package cli
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/staylor11x/spider-solitaire/internal/daily"
+	"github.com/staylor11x/spider-solitaire/internal/deck"
+	"github.com/staylor11x/spider-solitaire/internal/game"
+	"github.com/staylor11x/spider-solitaire/internal/printer"
+	"github.com/staylor11x/spider-solitaire/internal/record"
+	"github.com/staylor11x/spider-solitaire/internal/stats"
+	"github.com/staylor11x/spider-solitaire/internal/storage"
+)
+
+// Prompt is printed before each command is read
+const Prompt = "> "
+
+// maxHintsShown caps how many suggestions the hint command lists
+const maxHintsShown = 3
+
+// Options configures a Session
+type Options struct {
+	Render    printer.Options
+	SavePath  string // default file for save and load; storage.SavePath() when empty
+	StatsPath string // where finished and abandoned games are logged; not logged when empty
+	DailyPath string // where daily challenge results are recorded; not recorded when empty
+	Loaded    bool   // the game was loaded from a save, so it is saved as it stands
+}
+
+// Session is one interactive game. It is not safe for concurrent use.
+type Session struct {
+	game   *game.GameState
+	out    io.Writer
+	opts   Options
+	quit   bool
+	logged bool             // the current game is in the statistics already
+	entry  *stats.Game      // the current game's latest statistics entry, replaced if it is logged again
+	saved  bool             // the current game was saved or loaded and hasn't been played since
+	rec    *record.Recorder // nil for games loaded part way through, which can't be replayed from the deal
+}
+
+// NewSession starts a session on g, writing all output to out
+func NewSession(g *game.GameState, out io.Writer, opts Options) *Session {
+	s := &Session{game: g, out: out, opts: opts, saved: opts.Loaded}
+	s.resetLog()
+	s.startRecording()
+	return s
+}
+
+// Game returns the game being played (it changes after new or load)
+func (s *Session) Game() *game.GameState {
+	return s.game
+}
+
+// Run renders the game, then reads and executes commands until quit or end of input
+func (s *Session) Run(in io.Reader) error {
+	s.render()
+	sc := bufio.NewScanner(in)
+	for !s.quit {
+		fmt.Fprint(s.out, Prompt)
+		if !sc.Scan() {
+			fmt.Fprintln(s.out)
+			break
+		}
+		s.Exec(sc.Text())
+	}
+	return sc.Err()
+}
+
+// Exec runs a single command line and reports whether the session should end
+func (s *Session) Exec(line string) (quit bool) {
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return s.quit
+	}
+	name, args := strings.ToLower(fields[0]), fields[1:]
+
+	cmd, ok := commands[name]
+	if !ok {
+		fmt.Fprintf(s.out, "unknown command %q, type help for the list\n", name)
+		return s.quit
+	}
+	if err := cmd.run(s, args); err != nil {
+		fmt.Fprintf(s.out, "error: %s\n", friendlyError(err))
+	}
+	return s.quit
+}
+
+type command struct {
+	usage string
+	help  string
+	run   func(s *Session, args []string) error
+}
+
+// commands maps each name and alias to its handler. It is filled in by init
+// because help refers back to the table.
+var commands map[string]command
+
+// commandOrder is the order help lists commands in
+var commandOrder = []string{"move", "deal", "undo", "redo", "rewind", "auto", "hint", "new", "restart", "save", "load", "export", "show", "help", "quit"}
+
+// aliases follow the desktop keys: U undoes, Ctrl+Y redoes, R restarts and N deals anew
+var aliases = map[string]string{
+	"m": "move", "d": "deal", "u": "undo", "y": "redo", "h": "hint", "n": "new", "r": "restart",
+	"?": "help", "q": "quit", "exit": "quit",
+}
+
+func init() {
+	commands = map[string]command{
+		"move":    {"move <src> <idx> <dst>", "move the cards from position idx of pile src onto pile dst (also: move src:idx>dst)", (*Session).cmdMove},
+		"deal":    {"deal", "deal a row from the stock", (*Session).cmdDeal},
+		"undo":    {"undo", "take back the last move or deal", (*Session).cmdUndo},
+		"redo":    {"redo", "play the last undone move or deal again", (*Session).cmdRedo},
+		"rewind":  {"rewind", "undo everything back to the deal, then redo steps forward again", (*Session).cmdRewind},
+		"auto":    {"auto", "finish the game once the stock is empty and every card is face up", (*Session).cmdAuto},
+		"hint":    {"hint", "suggest the best moves", (*Session).cmdHint},
+		"new":     {"new [suits] [seed]", "start a new game (same suits and a random deal by default)", (*Session).cmdNew},
+		"restart": {"restart", "start this deal again from the beginning", (*Session).cmdRestart},
+		"save":    {"save [path]", "save the game", (*Session).cmdSave},
+		"load":    {"load [path]", "load a saved game", (*Session).cmdLoad},
+		"export":  {"export [path]", "write the game record, for -replay", (*Session).cmdExport},
+		"show":    {"show", "print the table again", (*Session).cmdShow},
+		"help":    {"help", "list commands", (*Session).cmdHelp},
+		"quit":    {"quit", "leave the game", (*Session).cmdQuit},
+	}
+	for alias, name := range aliases {
+		commands[alias] = commands[name]
+	}
+}
+
+func (s *Session) cmdMove(args []string) error {
+	var m game.Move
+	switch len(args) {
+	case 1:
+		var err error
+		if m, err = game.ParseMove(args[0]); err != nil || m.Kind != game.MoveTableau {
+			return errUsage("move")
+		}
+	case 3:
+		var idx [3]int
+		for i, a := range args {
+			n, err := strconv.Atoi(a)
+			if err != nil {
+				return errUsage("move")
+			}
+			idx[i] = n
+		}
+		m = game.TableauMove(idx[0], idx[1], idx[2])
+	default:
+		return errUsage("move")
+	}
+	return s.play(m)
+}
+
+func (s *Session) cmdDeal(args []string) error {
+	return s.play(game.DealMove())
+}
+
+func (s *Session) cmdUndo(args []string) error {
+	if err := s.game.Undo(); err != nil {
+		return err
+	}
+	s.afterAction()
+	return nil
+}
+
+func (s *Session) cmdRedo(args []string) error {
+	if err := s.game.Redo(); err != nil {
+		return err
+	}
+	s.afterAction()
+	return nil
+}
+
+func (s *Session) cmdRewind(args []string) error {
+	if err := s.game.Rewind(); err != nil {
+		return err
+	}
+	s.afterAction()
+	return nil
+}
+
+func (s *Session) cmdAuto(args []string) error {
+	if err := s.game.AutoComplete(); err != nil {
+		return err
+	}
+	s.afterAction()
+	return nil
+}
+
+// play applies a move, then redraws and announces a win or loss
+func (s *Session) play(m game.Move) error {
+	if err := s.game.Apply(m); err != nil {
+		return err
+	}
+	s.afterAction()
+	return nil
+}
+
+func (s *Session) afterAction() {
+	s.saved = false
+	s.render()
+	// undone out of a win or loss, the game goes on and is logged again when it ends
+	if s.logged && !s.game.Won && !s.game.Lost {
+		s.logged = false
+	}
+	for _, e := range s.game.LastEvents() {
+		switch e.Kind {
+		case game.EventGameWon:
+			s.logStats()
+			fmt.Fprintf(s.out, "You won! Score %d in %d moves. Type new to play again.\n", s.game.Score, s.game.Moves)
+		case game.EventGameLost:
+			s.logStats()
+			fmt.Fprintln(s.out, "No moves left, the game is lost. Type undo to go back, restart to try this deal again or new for another.")
+		}
+	}
+}
+
+func (s *Session) cmdHint(args []string) error {
+	hints := s.game.Hints()
+	if len(hints) == 0 {
+		fmt.Fprintln(s.out, "no moves available, try undo or new")
+		return nil
+	}
+	for _, h := range hints[:min(len(hints), maxHintsShown)] {
+		fmt.Fprintf(s.out, "  %-16s %s\n", commandFor(h.Move), h.Reason)
+	}
+	return nil
+}
+
+// commandFor spells a move as the command that would play it
+func commandFor(m game.Move) string {
+	if m.Kind == game.MoveDeal {
+		return "deal"
+	}
+	return fmt.Sprintf("move %d %d %d", m.Src, m.Start, m.Dst)
+}
+
+func (s *Session) cmdNew(args []string) error {
+	if len(args) > 2 {
+		return errUsage("new")
+	}
+	suits := s.game.SuitCount
+	seed := deck.RandomSeed()
+	if len(args) >= 1 {
+		n, err := strconv.Atoi(args[0])
+		if err != nil || !deck.SuitCount(n).Valid() {
+			return errors.New("suits must be 1, 2 or 4")
+		}
+		suits = deck.SuitCount(n)
+	}
+	if len(args) == 2 {
+		n, err := strconv.ParseUint(args[1], 10, 64)
+		if err != nil {
+			return errors.New("seed must be a whole number")
+		}
+		seed = n
+	}
+	g, err := game.DealSeededGame(suits, seed)
+	if err != nil {
+		return err
+	}
+	s.setGame(g)
+	return nil
+}
+
+func (s *Session) cmdRestart(args []string) error {
+	g, err := s.game.Restart()
+	if err != nil {
+		return err
+	}
+	s.setGame(g)
+	return nil
+}
+
+func (s *Session) cmdSave(args []string) error {
+	path, err := s.savePath(args)
+	if err != nil {
+		return err
+	}
+	if err := storage.SaveGame(path, s.game); err != nil {
+		return err
+	}
+	s.saved = true
+	fmt.Fprintf(s.out, "saved to %s\n", path)
+	return nil
+}
+
+func (s *Session) cmdLoad(args []string) error {
+	path, err := s.savePath(args)
+	if err != nil {
+		return err
+	}
+	g, err := storage.LoadGame(path)
+	if err != nil {
+		return err
+	}
+	s.setGame(g)
+	s.saved = true
+	return nil
+}
+
+func (s *Session) cmdExport(args []string) error {
+	if s.rec == nil {
+		return record.ErrGameInProgress
+	}
+	var path string
+	switch {
+	case len(args) > 1:
+		return errors.New("expected at most one path")
+	case len(args) == 1:
+		path = args[0]
+	default:
+		var err error
+		if path, err = storage.RecordPath(s.game.Seed); err != nil {
+			return err
+		}
+	}
+	if err := storage.SaveRecord(path, s.rec.Record()); err != nil {
+		return err
+	}
+	fmt.Fprintf(s.out, "exported to %s\n", path)
+	return nil
+}
+
+func (s *Session) savePath(args []string) (string, error) {
+	switch {
+	case len(args) > 1:
+		return "", errors.New("expected at most one path")
+	case len(args) == 1:
+		return args[0], nil
+	case s.opts.SavePath != "":
+		return s.opts.SavePath, nil
+	default:
+		return storage.SavePath()
+	}
+}
+
+func (s *Session) cmdShow(args []string) error {
+	s.render()
+	return nil
+}
+
+func (s *Session) cmdHelp(args []string) error {
+	for _, name := range commandOrder {
+		c := commands[name]
+		fmt.Fprintf(s.out, "  %-24s %s\n", c.usage, c.help)
+	}
+	fmt.Fprintln(s.out, "Piles and card positions count from 0, as printed.")
+	return nil
+}
+
+func (s *Session) cmdQuit(args []string) error {
+	s.quit = true
+	return nil
+}
+
+// Saved reports whether the current game was saved or loaded and hasn't been played
+// since, so it can be resumed as it stands rather than abandoned.
+func (s *Session) Saved() bool {
+	return s.saved
+}
+
+// Abandon logs the current game as abandoned if it is under way and wasn't won or lost.
+// Call it when the session ends without the game being saved to resume later.
+func (s *Session) Abandon() {
+	if !s.logged && stats.Started(s.game) {
+		s.logStats()
+	}
+}
+
+// setGame switches to g, abandoning the game it replaces
+func (s *Session) setGame(g *game.GameState) {
+	s.Abandon()
+	s.game = g
+	s.saved = false
+	s.resetLog()
+	s.startRecording()
+	s.render()
+}
+
+// startRecording records the current game from its deal, if it hasn't been played yet
+func (s *Session) startRecording() {
+	if s.rec != nil {
+		s.rec.Stop()
+	}
+	s.rec, _ = record.NewRecorder(s.game) // a game loaded part way through isn't recorded
+}
+
+// resetLog starts the statistics afresh for the current game. One already won or lost
+// was logged when it ended.
+func (s *Session) resetLog() {
+	s.entry = nil
+	if e, ok := stats.Finished(s.game); ok {
+		s.entry = &e
+	}
+	s.logged = s.entry != nil
+}
+
+// logStats adds the current game to the statistics, and to the daily challenge results
+// when it is a daily deal. A game logged before, and since undone out of its result,
+// replaces its earlier entry.
+func (s *Session) logStats() {
+	if s.logged {
+		return
+	}
+	s.logged = true
+	now := time.Now()
+	entry := stats.FromGame(s.game, now)
+	if s.opts.StatsPath != "" {
+		var err error
+		if s.entry != nil {
+			err = storage.ReplaceStats(s.opts.StatsPath, *s.entry, entry)
+		} else {
+			err = storage.AddStats(s.opts.StatsPath, entry)
+		}
+		if err != nil {
+			fmt.Fprintf(s.out, "error: could not record statistics: %v\n", err)
+		}
+	}
+	s.entry = &entry
+	if day, ok := daily.DateOf(s.game, now); ok && s.opts.DailyPath != "" {
+		if err := storage.AddDaily(s.opts.DailyPath, daily.FromGame(s.game, day)); err != nil {
+			fmt.Fprintf(s.out, "error: could not record daily challenge: %v\n", err)
+		}
+	}
+}
+
+func (s *Session) render() {
+	fmt.Fprint(s.out, printer.Render(s.game.View(), s.opts.Render))
+}
+
+func errUsage(name string) error {
+	return fmt.Errorf("usage: %s", commands[name].usage)
+}
@@ -0,0 +1,321 @@
+package cli
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/staylor11x/spider-solitaire/internal/daily"
+	"github.com/staylor11x/spider-solitaire/internal/deck"
+	"github.com/staylor11x/spider-solitaire/internal/game"
+	"github.com/staylor11x/spider-solitaire/internal/record"
+	"github.com/staylor11x/spider-solitaire/internal/stats"
+	"github.com/staylor11x/spider-solitaire/internal/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestSession(t *testing.T) (*Session, *bytes.Buffer) {
+	t.Helper()
+	g, err := game.DealSeededGame(deck.OneSuit, 3)
+	require.NoError(t, err)
+	var out bytes.Buffer
+	return NewSession(g, &out, Options{SavePath: filepath.Join(t.TempDir(), "save.json")}), &out
+}
+
+func TestRun_ScriptedGame(t *testing.T) {
+	s, out := newTestSession(t)
+
+	script := "deal\nundo\nd\nquit\ndeal\n"
+	require.NoError(t, s.Run(strings.NewReader(script)))
+
+	assert.Equal(t, 2, s.Game().Deals, "commands after quit must not run")
+	assert.Equal(t, 1, s.Game().Undos)
+	assert.Equal(t, 4, strings.Count(out.String(), "Deal: 3 (1-suit)"), "initial render plus one per action")
+}
+
+func TestRun_UndoRedo(t *testing.T) {
+	s, _ := newTestSession(t)
+	require.NoError(t, s.Run(strings.NewReader("deal\nundo\nredo\nu\ny\n")))
+
+	assert.Equal(t, 40, s.Game().View().StockCount, "the deal is back after each redo")
+	assert.Equal(t, 2, s.Game().Undos)
+}
+
+func TestRun_RewindAndRestart(t *testing.T) {
+	s, _ := newTestSession(t)
+	initial := s.Game().View()
+
+	require.NoError(t, s.Run(strings.NewReader("deal\ndeal\nrewind\n")))
+	assert.Equal(t, initial.Tableau, s.Game().View().Tableau)
+	require.NoError(t, s.Run(strings.NewReader("redo\n")))
+	assert.Equal(t, 40, s.Game().View().StockCount, "rewind keeps the deals for redo")
+
+	require.NoError(t, s.Run(strings.NewReader("restart\n")))
+	assert.Equal(t, initial.Tableau, s.Game().View().Tableau)
+	assert.Zero(t, s.Game().Deals, "restart starts the deal afresh")
+
+	require.NoError(t, s.Run(strings.NewReader("deal\nr\n")))
+	assert.Zero(t, s.Game().Deals, "r restarts, like R on the desktop")
+}
+
+func TestRun_LogsAbandonedGames(t *testing.T) {
+	g, err := game.DealSeededGame(deck.OneSuit, 3)
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "stats.json")
+	s := NewSession(g, io.Discard, Options{StatsPath: path})
+
+	// an untouched deal isn't a game yet; one that was played and replaced is abandoned
+	require.NoError(t, s.Run(strings.NewReader("new\ndeal\nrestart\n")))
+
+	l, err := storage.LoadStats(path)
+	require.NoError(t, err)
+	require.Len(t, l.Games, 1)
+	assert.Equal(t, stats.Abandoned, l.Games[0].Result)
+	assert.Equal(t, deck.OneSuit, l.Games[0].Suits)
+}
+
+func TestRun_RecordsDailyChallenge(t *testing.T) {
+	now := time.Now()
+	g, err := daily.Deal(now, deck.TwoSuits)
+	require.NoError(t, err)
+	dir := t.TempDir()
+	s := NewSession(g, io.Discard, Options{StatsPath: filepath.Join(dir, "stats.json"), DailyPath: filepath.Join(dir, "daily.json")})
+
+	// the second game isn't the daily deal, so only the first is recorded
+	require.NoError(t, s.Run(strings.NewReader("deal\nnew\ndeal\nnew\n")))
+
+	l, err := storage.LoadDaily(filepath.Join(dir, "daily.json"))
+	require.NoError(t, err)
+	require.Len(t, l.Entries, 1)
+	e, ok := l.Lookup(now, deck.TwoSuits)
+	require.True(t, ok)
+	assert.Equal(t, stats.Abandoned, e.Result)
+
+	// a challenge lost, undone and won again counts as won
+	g = oneMoveFromLosing()
+	g.SuitCount, g.Seed = deck.OneSuit, daily.Seed(now, deck.OneSuit)
+	s = NewSession(g, io.Discard, Options{DailyPath: filepath.Join(dir, "daily.json")})
+	require.NoError(t, s.Run(strings.NewReader("move 5 1 8\nundo\nauto\n")))
+
+	l, err = storage.LoadDaily(filepath.Join(dir, "daily.json"))
+	require.NoError(t, err)
+	e, ok = l.Lookup(now, deck.OneSuit)
+	require.True(t, ok)
+	assert.Equal(t, stats.Won, e.Result)
+}
+
+func TestRun_LogsWinAfterUndoingLoss(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "stats.json")
+	s := NewSession(oneMoveFromLosing(), io.Discard, Options{StatsPath: path})
+
+	require.NoError(t, s.Run(strings.NewReader("move 5 1 8\n")))
+	require.True(t, s.Game().Lost)
+	l, err := storage.LoadStats(path)
+	require.NoError(t, err)
+	require.Len(t, l.Games, 1)
+	assert.Equal(t, stats.Lost, l.Games[0].Result)
+
+	require.NoError(t, s.Run(strings.NewReader("undo\nauto\n")))
+	require.True(t, s.Game().Won)
+	s.Abandon()
+
+	l, err = storage.LoadStats(path)
+	require.NoError(t, err)
+	require.Len(t, l.Games, 1, "the game is counted once")
+	assert.Equal(t, stats.Won, l.Games[0].Result)
+}
+
+func TestSaved_UntilTheNextAction(t *testing.T) {
+	s, _ := newTestSession(t)
+	assert.False(t, s.Saved())
+
+	s.Exec("deal")
+	s.Exec("save")
+	assert.True(t, s.Saved(), "a saved game can be resumed, so quitting doesn't abandon it")
+
+	s.Exec("undo")
+	assert.False(t, s.Saved(), "played since the save")
+
+	s.Exec("load")
+	assert.True(t, s.Saved())
+	s.Exec("new")
+	assert.False(t, s.Saved())
+
+	g, err := game.DealSeededGame(deck.OneSuit, 3)
+	require.NoError(t, err)
+	s = NewSession(g, io.Discard, Options{Loaded: true})
+	assert.True(t, s.Saved(), "a game opened from a save starts out saved")
+}
+
+func TestRun_StopsAtEndOfInput(t *testing.T) {
+	s, _ := newTestSession(t)
+	require.NoError(t, s.Run(strings.NewReader("deal")))
+	assert.Equal(t, 1, s.Game().Deals)
+}
+
+func TestExec_Move(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+	}{
+		{name: "three numbers", line: "move 0 0 1"},
+		{name: "notation", line: "move 0:0>1"},
+		{name: "alias", line: "m 0 0 1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := &game.GameState{}
+			g.Tableau.Piles[0].AddCard(deck.Card{Suit: deck.Spades, Rank: deck.Seven}, true)
+			g.Tableau.Piles[1].AddCard(deck.Card{Suit: deck.Spades, Rank: deck.Eight}, true)
+			var out bytes.Buffer
+			s := NewSession(g, &out, Options{})
+
+			s.Exec(tt.line)
+			assert.NotContains(t, out.String(), "error")
+			assert.Equal(t, 2, g.Tableau.Piles[1].Size())
+		})
+	}
+}
+
+func TestExec_FriendlyErrors(t *testing.T) {
+	tests := []struct {
+		line string
+		want string
+	}{
+		{line: "undo", want: "there is nothing to undo"},
+		{line: "redo", want: "there is nothing to redo"},
+		{line: "auto", want: "auto needs an empty stock"},
+		{line: "move 0 0 0", want: "pick a different destination pile"},
+		{line: "move 0 0 12", want: "no such destination pile"},
+		{line: "move 0 0 1", want: "still face down"},
+		{line: "move 0", want: "usage: move <src> <idx> <dst>"},
+		{line: "new 3", want: "suits must be 1, 2 or 4"},
+		{line: "load", want: "no saved game found there"},
+		{line: "fly", want: `unknown command "fly"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			s, out := newTestSession(t)
+			s.Exec(tt.line)
+			assert.Contains(t, out.String(), tt.want)
+		})
+	}
+}
+
+func TestExec_LoadWordsSaveVersions(t *testing.T) {
+	for input, want := range map[string]string{
+		`{"version": 999}`: "made by a newer version",
+		`{"version": 0}`:   "not a valid saved game",
+	} {
+		t.Run(input, func(t *testing.T) {
+			s, out := newTestSession(t)
+			require.NoError(t, os.WriteFile(s.opts.SavePath, []byte(input), 0o600))
+			s.Exec("load")
+			assert.Contains(t, out.String(), want)
+		})
+	}
+}
+
+func TestExec_NotAcceptingIsWorded(t *testing.T) {
+	g := &game.GameState{}
+	g.Tableau.Piles[0].AddCard(deck.Card{Suit: deck.Spades, Rank: deck.Seven}, true)
+	g.Tableau.Piles[1].AddCard(deck.Card{Suit: deck.Spades, Rank: deck.Two}, true)
+	var out bytes.Buffer
+	s := NewSession(g, &out, Options{})
+
+	s.Exec("move 0 0 1")
+	assert.Contains(t, out.String(), "that pile can't take those cards")
+}
+
+func TestExec_NewSaveAndLoad(t *testing.T) {
+	s, out := newTestSession(t)
+
+	s.Exec("new 2 99")
+	assert.Equal(t, deck.TwoSuits, s.Game().SuitCount)
+	assert.Equal(t, uint64(99), s.Game().Seed)
+
+	s.Exec("deal")
+	s.Exec("save")
+	assert.Contains(t, out.String(), "saved to")
+
+	s.Exec("new 1 1")
+	s.Exec("load")
+	assert.Equal(t, uint64(99), s.Game().Seed)
+	assert.Equal(t, 1, s.Game().Deals)
+}
+
+func TestExec_Export(t *testing.T) {
+	s, out := newTestSession(t)
+	path := filepath.Join(t.TempDir(), "game.txt")
+
+	s.Exec("deal")
+	s.Exec("undo")
+	s.Exec("deal")
+	s.Exec("export " + path)
+	assert.Contains(t, out.String(), "exported to "+path)
+
+	rec, err := storage.LoadRecord(path)
+	require.NoError(t, err)
+	replayed, err := record.Replay(rec)
+	require.NoError(t, err)
+	assert.Equal(t, s.Game().View().Tableau, replayed.View().Tableau)
+
+	// a game loaded after it was played has no record to export
+	s.Exec("save")
+	s.Exec("load")
+	out.Reset()
+	s.Exec("export " + path)
+	assert.Contains(t, out.String(), "can't be exported")
+}
+
+func TestExec_Hint(t *testing.T) {
+	s, out := newTestSession(t)
+	s.Exec("hint")
+	assert.Contains(t, out.String(), "move ")
+	assert.LessOrEqual(t, strings.Count(out.String(), "\n"), maxHintsShown)
+}
+
+// oneMoveFromLosing has six runs completed and every card face up. Moving the Jack of
+// Hearts onto the Queen of Spades (move 5 1 8) loses; auto-complete wins from here.
+func oneMoveFromLosing() *game.GameState {
+	g := game.AlmostWon()
+	g.Completed = g.Completed[:game.TotalRunsToWin-2]
+	g.SuitCount, g.Tableau = deck.TwoSuits, game.Tableau{}
+	for i, pile := range []string{
+		"7S 8S", "3H 5S 7H JS", "KH 9H TH 3S 6S", "9S 6H", "AH 2H 8H",
+		"4H JH", "5H KS", "TS 2S", "QH AS QS", "4S",
+	} {
+		for _, c := range strings.Fields(pile) {
+			rank := deck.Rank(strings.IndexByte("A23456789TJQK", c[0]) + 1)
+			suit := deck.Spades
+			if c[1] == 'H' {
+				suit = deck.Hearts
+			}
+			g.Tableau.Piles[i].AddCard(deck.Card{Suit: suit, Rank: rank}, true)
+		}
+	}
+	return g
+}
+
+func TestExec_AnnouncesWin(t *testing.T) {
+	var out bytes.Buffer
+	s := NewSession(game.AlmostWon(), &out, Options{})
+
+	s.Exec("move 1 0 0")
+	assert.Contains(t, out.String(), "You won!")
+}
+
+func TestExec_Auto(t *testing.T) {
+	var out bytes.Buffer
+	s := NewSession(game.AlmostWon(), &out, Options{})
+
+	s.Exec("auto")
+	assert.Contains(t, out.String(), "You won!")
+	s.Exec("undo")
+	assert.False(t, s.Game().Won)
+}
@@ -0,0 +1,185 @@
+// Package daily is the daily challenge: one deal per calendar day and difficulty, the
+// same for every player, and a log of the best attempt at each day's challenge, which
+// the calendar and streak are drawn from.
+package daily
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/staylor11x/spider-solitaire/internal/deck"
+	"github.com/staylor11x/spider-solitaire/internal/game"
+	"github.com/staylor11x/spider-solitaire/internal/jsonlog"
+	"github.com/staylor11x/spider-solitaire/internal/stats"
+)
+
+// Version is the schema version of the daily results file
+const Version = 1
+
+// ErrInvalidLog is returned for a daily challenge file that can't be read
+var ErrInvalidLog = errors.New("invalid daily challenge file")
+
+// Seed is the deal number of the challenge on day at the given difficulty. Only the
+// day's date counts, in day's own location, so players anywhere get the same deal
+// for the same date.
+func Seed(day time.Time, suits deck.SuitCount) uint64 {
+	y, m, d := day.Date()
+	date := uint64(y)*10000 + uint64(m)*100 + uint64(d)
+	return deck.NewRNG(date*10 + uint64(suits)).Uint64()
+}
+
+// Deal deals the challenge for day at the given difficulty
+func Deal(day time.Time, suits deck.SuitCount) (*game.GameState, error) {
+	return game.DealSeededGame(suits, Seed(day, suits))
+}
+
+// Key is the date of day as written in the log, YYYY-MM-DD
+func Key(day time.Time) string {
+	return day.Format(time.DateOnly)
+}
+
+// DateOf reports which day's challenge g is, checking the day of now and the day before
+// so a challenge started before midnight still counts for the day it was dealt
+func DateOf(g *game.GameState, now time.Time) (time.Time, bool) {
+	for _, day := range []time.Time{now, now.AddDate(0, 0, -1)} {
+		if g.Seed == Seed(day, g.SuitCount) {
+			return day, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// Entry is how one day's challenge went at one difficulty
+type Entry struct {
+	Date     string // Key of the day
+	Suits    deck.SuitCount
+	Result   stats.Result
+	Moves    int
+	Score    int
+	Duration time.Duration // time played
+}
+
+// FromGame makes the entry for g as the challenge of day, as it stands now
+func FromGame(g *game.GameState, day time.Time) Entry {
+	s := stats.FromGame(g, day)
+	return Entry{Date: Key(day), Suits: s.Suits, Result: s.Result, Moves: s.Moves, Score: s.Score, Duration: s.Duration}
+}
+
+// better reports whether e is a better result than old: a win beats anything else,
+// then the higher score wins
+func (e Entry) better(old Entry) bool {
+	if (e.Result == stats.Won) != (old.Result == stats.Won) {
+		return e.Result == stats.Won
+	}
+	return e.Score > old.Score
+}
+
+// Log is the player's daily challenges, one entry per day and difficulty, oldest first
+type Log struct {
+	Entries []Entry
+}
+
+// Record adds e to the log. A day played more than once keeps its best result.
+func (l *Log) Record(e Entry) {
+	for i, old := range l.Entries {
+		if old.Date == e.Date && old.Suits == e.Suits {
+			if e.better(old) {
+				l.Entries[i] = e
+			}
+			return
+		}
+	}
+	l.Entries = append(l.Entries, e)
+}
+
+// Lookup returns the entry for day at the given difficulty
+func (l *Log) Lookup(day time.Time, suits deck.SuitCount) (Entry, bool) {
+	key := Key(day)
+	for _, e := range l.Entries {
+		if e.Date == key && e.Suits == suits {
+			return e, true
+		}
+	}
+	return Entry{}, false
+}
+
+// Won reports whether the challenge of day was won at the given difficulty
+func (l *Log) Won(day time.Time, suits deck.SuitCount) bool {
+	e, ok := l.Lookup(day, suits)
+	return ok && e.Result == stats.Won
+}
+
+// Streak counts the days in a row, up to today, whose challenge was won at the given
+// difficulty. Today not being won yet doesn't break the streak until the day is over.
+func (l *Log) Streak(suits deck.SuitCount, today time.Time) int {
+	day := today
+	if !l.Won(day, suits) {
+		day = day.AddDate(0, 0, -1)
+	}
+	n := 0
+	for l.Won(day, suits) {
+		n++
+		day = day.AddDate(0, 0, -1)
+	}
+	return n
+}
+
+// savedEntry is an Entry as written to the results file. The date is kept as text so the
+// file reads the same whatever time zone it was written in.
+type savedEntry struct {
+	Date       string `json:"date"`
+	Suits      int    `json:"suits"`
+	Result     string `json:"result"`
+	Moves      int    `json:"moves"`
+	Score      int    `json:"score"`
+	DurationMS int64  `json:"duration_ms"`
+}
+
+// Write encodes the results, one entry per day and difficulty
+func (l *Log) Write(w io.Writer) error {
+	entries := make([]savedEntry, len(l.Entries))
+	for i, e := range l.Entries {
+		entries[i] = savedEntry{
+			Date:       e.Date,
+			Suits:      int(e.Suits),
+			Result:     string(e.Result),
+			Moves:      e.Moves,
+			Score:      e.Score,
+			DurationMS: e.Duration.Milliseconds(),
+		}
+	}
+	return jsonlog.Write(w, Version, "entries", entries)
+}
+
+// Read decodes results written by Write. An entry repeated for the same day and
+// difficulty, as a hand-edited file might have, keeps the better result.
+func Read(r io.Reader) (*Log, error) {
+	entries, err := jsonlog.Read[savedEntry](r, Version, "entries")
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidLog, err)
+	}
+	l := &Log{}
+	for _, se := range entries {
+		e := Entry{
+			Date:     se.Date,
+			Suits:    deck.SuitCount(se.Suits),
+			Result:   stats.Result(se.Result),
+			Moves:    se.Moves,
+			Score:    se.Score,
+			Duration: time.Duration(se.DurationMS) * time.Millisecond,
+		}
+		if _, err := time.Parse(time.DateOnly, e.Date); err != nil {
+			return nil, fmt.Errorf("%w: bad date %q", ErrInvalidLog, se.Date)
+		}
+		if !e.Suits.Valid() {
+			return nil, fmt.Errorf("%w: bad suit count %d", ErrInvalidLog, se.Suits)
+		}
+		if !e.Result.Valid() {
+			return nil, fmt.Errorf("%w: bad result %q", ErrInvalidLog, se.Result)
+		}
+		l.Record(e)
+	}
+	return l, nil
+}
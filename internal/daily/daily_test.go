@@ -0,0 +1,112 @@
+package daily
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/staylor11x/spider-solitaire/internal/deck"
+	"github.com/staylor11x/spider-solitaire/internal/stats"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var today = time.Date(2026, 10, 16, 9, 30, 0, 0, time.UTC)
+
+func TestSeed_DependsOnDateAndSuits(t *testing.T) {
+	evening := time.Date(2026, 10, 16, 23, 59, 0, 0, time.FixedZone("AEST", 10*60*60))
+	assert.Equal(t, Seed(today, deck.OneSuit), Seed(evening, deck.OneSuit), "the time of day doesn't matter")
+	assert.NotEqual(t, Seed(today, deck.OneSuit), Seed(today, deck.TwoSuits))
+	assert.NotEqual(t, Seed(today, deck.OneSuit), Seed(today.AddDate(0, 0, 1), deck.OneSuit))
+	assert.NotEqual(t, Seed(today, deck.OneSuit), Seed(today.AddDate(1, 0, 0), deck.OneSuit))
+
+	a, err := Deal(today, deck.TwoSuits)
+	require.NoError(t, err)
+	b, err := Deal(evening, deck.TwoSuits)
+	require.NoError(t, err)
+	assert.Equal(t, a.View(), b.View(), "everyone gets the same deal")
+}
+
+func TestDateOf(t *testing.T) {
+	g, err := Deal(today, deck.FourSuits)
+	require.NoError(t, err)
+
+	day, ok := DateOf(g, today)
+	assert.True(t, ok)
+	assert.Equal(t, "2026-10-16", Key(day))
+
+	day, ok = DateOf(g, today.Add(20*time.Hour))
+	assert.True(t, ok, "finished after midnight")
+	assert.Equal(t, "2026-10-16", Key(day))
+
+	_, ok = DateOf(g, today.AddDate(0, 0, 2))
+	assert.False(t, ok)
+
+	g, err = Deal(today, deck.OneSuit)
+	require.NoError(t, err)
+	g.SuitCount = deck.FourSuits
+	_, ok = DateOf(g, today)
+	assert.False(t, ok, "the seed is only the challenge at its own difficulty")
+}
+
+func TestRecord_KeepsBestResult(t *testing.T) {
+	l := &Log{}
+	l.Record(Entry{Date: "2026-10-16", Suits: deck.OneSuit, Result: stats.Lost, Score: 450})
+	l.Record(Entry{Date: "2026-10-16", Suits: deck.TwoSuits, Result: stats.Abandoned, Score: 480})
+	l.Record(Entry{Date: "2026-10-16", Suits: deck.OneSuit, Result: stats.Won, Score: 600})
+	l.Record(Entry{Date: "2026-10-16", Suits: deck.OneSuit, Result: stats.Lost, Score: 700})
+	l.Record(Entry{Date: "2026-10-16", Suits: deck.OneSuit, Result: stats.Won, Score: 650})
+
+	require.Len(t, l.Entries, 2)
+	e, ok := l.Lookup(today, deck.OneSuit)
+	require.True(t, ok)
+	assert.Equal(t, stats.Won, e.Result)
+	assert.Equal(t, 650, e.Score)
+	assert.True(t, l.Won(today, deck.OneSuit))
+	assert.False(t, l.Won(today, deck.TwoSuits))
+}
+
+func TestStreak(t *testing.T) {
+	l := &Log{}
+	won := func(daysAgo int) {
+		l.Record(Entry{Date: Key(today.AddDate(0, 0, -daysAgo)), Suits: deck.OneSuit, Result: stats.Won})
+	}
+	assert.Zero(t, l.Streak(deck.OneSuit, today))
+
+	won(1)
+	won(2)
+	won(4)
+	assert.Equal(t, 2, l.Streak(deck.OneSuit, today), "today isn't over yet")
+	won(0)
+	assert.Equal(t, 3, l.Streak(deck.OneSuit, today))
+	assert.Zero(t, l.Streak(deck.TwoSuits, today))
+	assert.Zero(t, l.Streak(deck.OneSuit, today.AddDate(0, 0, 2)), "a missed day breaks the streak")
+}
+
+func TestWriteRead_RoundTrip(t *testing.T) {
+	l := &Log{}
+	l.Record(Entry{Date: "2026-10-15", Suits: deck.OneSuit, Result: stats.Won, Moves: 120, Score: 640, Duration: 90 * time.Second})
+	l.Record(Entry{Date: "2026-10-16", Suits: deck.FourSuits, Result: stats.Abandoned, Moves: 3, Score: 497, Duration: 1500 * time.Millisecond})
+
+	var buf bytes.Buffer
+	require.NoError(t, l.Write(&buf))
+	got, err := Read(&buf)
+	require.NoError(t, err)
+	assert.Equal(t, l, got)
+}
+
+func TestRead_RejectsBadInput(t *testing.T) {
+	for name, input := range map[string]string{
+		"not json":   "daily",
+		"newer":      `{"version": 2}`,
+		"bad date":   `{"version": 1, "entries": [{"date": "16/10/2026", "suits": 1, "result": "won"}]}`,
+		"bad suits":  `{"version": 1, "entries": [{"date": "2026-10-16", "suits": 3, "result": "won"}]}`,
+		"bad result": `{"version": 1, "entries": [{"date": "2026-10-16", "suits": 1, "result": "draw"}]}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := Read(strings.NewReader(input))
+			assert.ErrorIs(t, err, ErrInvalidLog)
+		})
+	}
+}
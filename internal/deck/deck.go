@@ -2,8 +2,6 @@ package deck
 
 import (
 	"errors"
-	"math/rand"
-	"time"
 )
 
 type SuitCount int
@@ -19,6 +17,11 @@ const (
 	FourSuitDeckCount DeckCount = 2
 )
 
+// Valid reports whether the suit count is one Spider supports (1, 2 or 4)
+func (s SuitCount) Valid() bool {
+	return s == OneSuit || s == TwoSuits || s == FourSuits
+}
+
 // NewSpiderDeck creates a 104-card deck for Spider Solitaire with the specified number of suits
 func NewSpiderDeck(suitCount SuitCount) *Deck {
 	cards := make([]Card, 0, 104)
@@ -91,10 +94,16 @@ func NewMultiDeck(n int) Deck {
 
 // Shuffle randomizes the order of the deck
 func (d *Deck) Shuffle() {
-	r := rand.New(rand.NewSource(time.Now().UnixNano()))
-	r.Shuffle(len(d.cards), func(i, j int) {
+	d.ShuffleWith(NewRNG(RandomSeed()))
+}
+
+// ShuffleWith randomizes the order of the deck using the given generator.
+// The same generator state always produces the same order (Fisher-Yates).
+func (d *Deck) ShuffleWith(r *RNG) {
+	for i := len(d.cards) - 1; i > 0; i-- {
+		j := r.Intn(i + 1)
 		d.cards[i], d.cards[j] = d.cards[j], d.cards[i]
-	})
+	}
 }
 
 // Draw removes and returns the top card from the deck
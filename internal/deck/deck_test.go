@@ -131,3 +131,35 @@ func TestNewSpiderDEck(t *testing.T) {
 		}
 	})
 }
+
+func TestShuffleWith_IsDeterministic(t *testing.T) {
+	d1 := NewSpiderDeck(FourSuits)
+	d2 := NewSpiderDeck(FourSuits)
+	d1.ShuffleWith(NewRNG(42))
+	d2.ShuffleWith(NewRNG(42))
+	assert.Equal(t, d1.Cards(), d2.Cards(), "same seed should produce the same order")
+
+	d3 := NewSpiderDeck(FourSuits)
+	d3.ShuffleWith(NewRNG(43))
+	assert.NotEqual(t, d1.Cards(), d3.Cards(), "different seeds should produce different orders")
+}
+
+func TestShuffleWith_GoldenOrder(t *testing.T) {
+	// Pinned so a change to the RNG or shuffle (or a Go upgrade) can't silently
+	// change every shared deal number.
+	d := NewStandardDeck()
+	d.ShuffleWith(NewRNG(42))
+	cards := d.Cards()
+
+	assert.Equal(t, Card{Suit: Spades, Rank: Seven}, cards[0])
+	assert.Equal(t, Card{Suit: Diamonds, Rank: Three}, cards[1])
+	assert.Equal(t, Card{Suit: Spades, Rank: Ten}, cards[51])
+}
+
+func TestSuitCountValid(t *testing.T) {
+	assert.True(t, OneSuit.Valid())
+	assert.True(t, TwoSuits.Valid())
+	assert.True(t, FourSuits.Valid())
+	assert.False(t, SuitCount(3).Valid())
+	assert.False(t, SuitCount(0).Valid())
+}
@@ -0,0 +1,48 @@
+package deck
+
+import "math/rand/v2"
+
+// RNG is a small deterministic pseudo-random generator (SplitMix64).
+//
+// The sequence deliberately doesn't come from math/rand: the algorithm is spelled
+// out in this file, so a seed produces the same sequence on every platform and
+// every Go release. That is what makes a deal number shareable and reproducible.
+// Only RandomSeed uses math/rand, to pick a seed when the player doesn't give one.
+type RNG struct {
+	state uint64
+}
+
+// NewRNG creates a generator seeded with the given value
+func NewRNG(seed uint64) *RNG {
+	return &RNG{state: seed}
+}
+
+// RandomSeed returns a fresh, non-deterministic seed for players who just want a new deal
+func RandomSeed() uint64 {
+	return rand.Uint64()
+}
+
+// Uint64 returns the next 64-bit value in the sequence
+func (r *RNG) Uint64() uint64 {
+	r.state += 0x9e3779b97f4a7c15
+	z := r.state
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return z ^ (z >> 31)
+}
+
+// Intn returns a uniformly distributed value in [0, n). It panics if n <= 0.
+func (r *RNG) Intn(n int) int {
+	if n <= 0 {
+		panic("deck: Intn called with n <= 0")
+	}
+	bound := uint64(n)
+	// reject the low values that would bias the modulo (threshold = 2^64 mod n)
+	threshold := -bound % bound
+	for {
+		v := r.Uint64()
+		if v >= threshold {
+			return int(v % bound)
+		}
+	}
+}
@@ -0,0 +1,28 @@
+package deck
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRNG_MatchesSplitMix64Reference(t *testing.T) {
+	// reference outputs of SplitMix64 seeded with 0
+	r := NewRNG(0)
+	assert.Equal(t, uint64(0xe220a8397b1dcdaf), r.Uint64())
+	assert.Equal(t, uint64(0x6e789e6aa1b965f4), r.Uint64())
+	assert.Equal(t, uint64(0x06c45d188009454f), r.Uint64())
+}
+
+func TestRNG_IntnStaysInRange(t *testing.T) {
+	r := NewRNG(7)
+	for range 1000 {
+		v := r.Intn(13)
+		assert.GreaterOrEqual(t, v, 0)
+		assert.Less(t, v, 13)
+	}
+}
+
+func TestRNG_IntnPanicsOnNonPositive(t *testing.T) {
+	assert.Panics(t, func() { NewRNG(1).Intn(0) })
+}
@@ -0,0 +1,131 @@
+package game
+
+// autoCompleteMaxNodes bounds the search for a finishing line. With the stock gone and
+// every card showing, a winnable position is normally solved within a few dozen nodes;
+// the bound keeps a stuck-looking position from stalling the caller.
+const autoCompleteMaxNodes = 5000
+
+// CanAutoComplete reports whether the rest of the game is busywork: the stock is empty,
+// every tableau card is face up, and the engine's own moves can assemble all the
+// remaining runs
+func (g *GameState) CanAutoComplete() bool {
+	_, ok := g.AutoCompleteLine()
+	return ok
+}
+
+// AutoComplete plays out a game that CanAutoComplete accepts, as one action. Every move
+// counts and scores as if played by hand and emits its usual events, after an
+// EventAutoCompleted giving how many moves follow. A single Undo takes them all back.
+// ErrCannotAutoComplete is returned, with nothing changed, for any other position.
+func (g *GameState) AutoComplete() error {
+	moves, ok := g.AutoCompleteLine()
+	if !ok {
+		return ErrCannotAutoComplete
+	}
+	return g.playLine(moves)
+}
+
+// AutoCompleteWith plays a line found earlier by AutoCompleteLine, as AutoComplete would,
+// without searching for it again. ErrCannotAutoComplete is returned, with nothing
+// changed, when the line doesn't win from the current position.
+func (g *GameState) AutoCompleteWith(moves []Move) error {
+	if !g.busywork() {
+		return ErrCannotAutoComplete
+	}
+	// try the line on a copy first, so a stale one can't stop partway
+	c := g.Clone()
+	for _, m := range moves {
+		if m.Kind != MoveTableau || c.Apply(m) != nil {
+			return ErrCannotAutoComplete
+		}
+	}
+	if !c.Won {
+		return ErrCannotAutoComplete
+	}
+	return g.playLine(moves)
+}
+
+// playLine makes the moves of a winning line as one auto-complete action
+func (g *GameState) playLine(moves []Move) error {
+	g.beginAction()
+	defer g.publish()
+	g.beginCommand()
+	defer g.endCommand()
+	g.pending.auto = true
+	g.startClock()
+
+	g.emit(Event{Kind: EventAutoCompleted, Count: len(moves)})
+	for _, m := range moves {
+		sequence, err := g.validateMove(m.Src, m.Start, m.Dst)
+		if err != nil {
+			return err
+		}
+		if err := g.playMove(m.Src, m.Start, m.Dst, sequence); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AutoCompleteLine returns the moves AutoComplete would play, and whether there are any.
+// The position must have no stock and no face-down cards; those checks are cheap, the
+// search that follows isn't. It plays moves on clones, best-ranked first, and skips
+// positions it has already seen.
+func (g *GameState) AutoCompleteLine() ([]Move, bool) {
+	if !g.busywork() {
+		return nil, false
+	}
+	s := autoSearch{seen: make(map[string]bool)}
+	if !s.dfs(g.Clone()) {
+		return nil, false
+	}
+	return s.path, true
+}
+
+// busywork reports whether the game is still on with the stock gone and every card face up
+func (g *GameState) busywork() bool {
+	if g.Won || g.Lost || len(g.Stock) > 0 {
+		return false
+	}
+	for _, p := range g.Tableau.Piles {
+		for _, c := range p.cards {
+			if !c.FaceUp {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// autoSearch is the state of one AutoCompleteLine search
+type autoSearch struct {
+	seen  map[string]bool
+	path  []Move
+	nodes int
+}
+
+// dfs reports whether g can be won, leaving the winning line in s.path
+func (s *autoSearch) dfs(g *GameState) bool {
+	if g.Won {
+		return true
+	}
+	key := g.PositionKey()
+	if g.Lost || s.seen[key] || s.nodes >= autoCompleteMaxNodes {
+		return false
+	}
+	s.seen[key] = true
+	s.nodes++
+
+	for _, m := range g.OrderedMoves() {
+		child := g.Clone()
+		if err := child.Apply(m); err != nil {
+			continue
+		}
+		s.path = append(s.path, m)
+		if s.dfs(child) {
+			return true
+		}
+		s.path = s.path[:len(s.path)-1]
+	}
+	return false
+}
@@ -0,0 +1,116 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/staylor11x/spider-solitaire/internal/deck"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// almostWonGame has six runs completed and the last two split across three piles, with
+// the low hearts sitting on the spades that are waiting for the rest of their run
+func almostWonGame() *GameState {
+	g := &GameState{Score: ClassicScoring{}.InitialScore()}
+	for range 6 {
+		g.Completed = append(g.Completed, CompletedRun(deck.Clubs))
+	}
+	spades, hearts := CompletedRun(deck.Spades), CompletedRun(deck.Hearts)
+	g.Tableau.Piles[0].AddCards(spades[:6]) // K-8
+	g.Tableau.Piles[0].AddCards(hearts[9:]) // 4-A
+	g.Tableau.Piles[1].AddCards(spades[6:]) // 7-A
+	g.Tableau.Piles[2].AddCards(hearts[:9]) // K-5
+	return g
+}
+
+func TestCanAutoComplete(t *testing.T) {
+	assert.True(t, almostWonGame().CanAutoComplete())
+
+	g := almostWonGame()
+	g.Stock = []deck.Card{{Suit: deck.Spades, Rank: deck.Ace}}
+	assert.False(t, g.CanAutoComplete(), "cards left in the stock")
+
+	g = almostWonGame()
+	g.Tableau.Piles[3].AddCard(deck.Card{Suit: deck.Clubs, Rank: deck.Two}, false)
+	assert.False(t, g.CanAutoComplete(), "a face-down card")
+
+	dealt, err := DealSeededGame(deck.OneSuit, 1)
+	require.NoError(t, err)
+	assert.False(t, dealt.CanAutoComplete())
+	assert.ErrorIs(t, dealt.AutoComplete(), ErrCannotAutoComplete)
+	assert.Zero(t, dealt.Moves, "nothing played")
+}
+
+func TestAutoComplete_WinsAsOneAction(t *testing.T) {
+	g := almostWonGame()
+	before := g.View()
+
+	require.NoError(t, g.AutoComplete())
+	assert.True(t, g.Won)
+	assert.Len(t, g.Completed, TotalRunsToWin)
+	assert.Equal(t, 2, g.Moves)
+	assert.Equal(t, 500-2+2*100, g.Score, "each move and run scores as usual")
+
+	events := g.LastEvents()
+	require.NotEmpty(t, events)
+	assert.Equal(t, Event{Kind: EventAutoCompleted, Count: 2}, events[0])
+	assert.Equal(t, 2, countKind(events, EventMoveApplied))
+	assert.Equal(t, EventGameWon, events[len(events)-1].Kind)
+
+	require.NoError(t, g.Undo())
+	assert.False(t, g.Won)
+	after := g.View()
+	assert.Equal(t, before.Tableau, after.Tableau, "one undo takes back every move")
+	assert.Equal(t, before.CompletedCount, after.CompletedCount)
+	assert.Equal(t, 500-1, g.Score)
+
+	require.NoError(t, g.Redo())
+	assert.True(t, g.Won)
+	assert.Equal(t, 4, g.Moves)
+	assert.Equal(t, Event{Kind: EventAutoCompleted, Count: 2}, g.LastEvents()[0], "redo announces it like the original")
+}
+
+func TestRedo_AnnouncesAOneMoveAutoComplete(t *testing.T) {
+	g := AlmostWon()
+	require.NoError(t, g.AutoComplete())
+	original := g.LastEvents()
+	require.Equal(t, Event{Kind: EventAutoCompleted, Count: 1}, original[0])
+
+	require.NoError(t, g.Undo())
+	require.NoError(t, g.Redo())
+	assert.Equal(t, original, g.LastEvents(), "redo emits what the auto-complete did")
+
+	require.NoError(t, g.Undo())
+	require.NoError(t, g.MoveSequence(1, 0, 0))
+	require.NoError(t, g.Undo())
+	require.NoError(t, g.Redo())
+	assert.Equal(t, EventMoveApplied, g.LastEvents()[0].Kind, "the same move played by hand is redone as a move")
+}
+
+func TestAutoCompleteWith_PlaysAFoundLine(t *testing.T) {
+	g := almostWonGame()
+	line, ok := g.AutoCompleteLine()
+	require.True(t, ok)
+
+	stale := almostWonGame()
+	require.NoError(t, stale.Apply(line[0]))
+	before := stale.View()
+	assert.ErrorIs(t, stale.AutoCompleteWith(line), ErrCannotAutoComplete, "the line was for an earlier position")
+	assert.Equal(t, before.Tableau, stale.View().Tableau, "nothing played")
+	assert.Equal(t, 1, stale.Moves)
+	assert.ErrorIs(t, g.AutoCompleteWith(line[:1]), ErrCannotAutoComplete, "a line that doesn't win")
+
+	require.NoError(t, g.AutoCompleteWith(line))
+	assert.True(t, g.Won)
+	assert.Equal(t, Event{Kind: EventAutoCompleted, Count: len(line)}, g.LastEvents()[0])
+}
+
+func countKind(events []Event, kind EventKind) int {
+	n := 0
+	for _, e := range events {
+		if e.Kind == kind {
+			n++
+		}
+	}
+	return n
+}
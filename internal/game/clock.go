@@ -0,0 +1,76 @@
+package game
+
+import "time"
+
+// Clock supplies the current time to the game timer. Tests inject a fake one.
+type Clock interface {
+	Now() time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time { return time.Now() }
+
+// SetClock replaces the time source, carrying over the time already played
+func (g *GameState) SetClock(c Clock) {
+	running := g.clockRunning()
+	g.stopClock()
+	g.clock = c
+	if running {
+		g.startClock()
+	}
+}
+
+// Elapsed returns the play time so far. The clock starts with the first action,
+// stops while paused, and stops for good once the game is won or lost.
+func (g *GameState) Elapsed() time.Duration {
+	if g.clockRunning() {
+		return g.elapsed + g.now().Sub(g.runningSince)
+	}
+	return g.elapsed
+}
+
+// Pause stops the game clock, e.g. while a menu or help screen is open
+func (g *GameState) Pause() {
+	g.stopClock()
+	g.paused = true
+}
+
+// Resume restarts the game clock after Pause (it stays stopped if the game is over)
+func (g *GameState) Resume() {
+	g.paused = false
+	g.startClock()
+}
+
+// Paused reports whether the clock has been paused with Pause
+func (g *GameState) Paused() bool {
+	return g.paused
+}
+
+func (g *GameState) now() time.Time {
+	if g.clock == nil {
+		return systemClock{}.Now()
+	}
+	return g.clock.Now()
+}
+
+func (g *GameState) clockRunning() bool {
+	return !g.runningSince.IsZero()
+}
+
+// startClock begins timing unless the clock is paused, already running or the game is over
+func (g *GameState) startClock() {
+	if g.paused || g.Won || g.Lost || g.clockRunning() {
+		return
+	}
+	g.runningSince = g.now()
+}
+
+// stopClock banks the running time into elapsed
+func (g *GameState) stopClock() {
+	if !g.clockRunning() {
+		return
+	}
+	g.elapsed += g.now().Sub(g.runningSince)
+	g.runningSince = time.Time{}
+}
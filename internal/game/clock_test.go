@@ -0,0 +1,124 @@
+package game
+
+import (
+	"testing"
+	"time"
+
+	"github.com/staylor11x/spider-solitaire/internal/deck"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClock is a manually advanced Clock
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func (c *fakeClock) Advance(d time.Duration) { c.now = c.now.Add(d) }
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)}
+}
+
+// movableGame returns a game with a 10 on pile 0 that can move onto the jack on pile 1
+func movableGame(clock Clock) *GameState {
+	g := &GameState{}
+	g.SetClock(clock)
+	g.Tableau.Piles[0] = newPile(makeCardInPile(deck.Spades, deck.Ten, true))
+	g.Tableau.Piles[1] = newPile(makeCardInPile(deck.Hearts, deck.Jack, true))
+	return g
+}
+
+func TestClock_StartsWithFirstAction(t *testing.T) {
+	clock := newFakeClock()
+	g := movableGame(clock)
+
+	clock.Advance(time.Minute)
+	assert.Zero(t, g.Elapsed(), "clock should not run before the first action")
+
+	require.NoError(t, g.MoveSequence(0, 0, 1))
+	clock.Advance(30 * time.Second)
+	assert.Equal(t, 30*time.Second, g.Elapsed())
+	assert.Equal(t, 30*time.Second, g.View().Elapsed)
+}
+
+func TestClock_PauseAndResume(t *testing.T) {
+	clock := newFakeClock()
+	g := movableGame(clock)
+	require.NoError(t, g.MoveSequence(0, 0, 1))
+
+	clock.Advance(10 * time.Second)
+	g.Pause()
+	assert.True(t, g.Paused())
+	clock.Advance(time.Hour)
+	assert.Equal(t, 10*time.Second, g.Elapsed(), "paused time should not count")
+
+	g.Resume()
+	assert.False(t, g.Paused())
+	clock.Advance(5 * time.Second)
+	assert.Equal(t, 15*time.Second, g.Elapsed())
+}
+
+func TestClock_StopsWhenWon(t *testing.T) {
+	clock := newFakeClock()
+	g := AlmostWon()
+	g.SetClock(clock)
+	g.Tableau.Piles[2].AddCard(deck.Card{Suit: deck.Hearts, Rank: deck.Five}, true)
+	g.Tableau.Piles[3].AddCard(deck.Card{Suit: deck.Hearts, Rank: deck.Six}, true)
+
+	require.NoError(t, g.MoveSequence(2, 0, 3))
+	clock.Advance(time.Minute)
+	require.NoError(t, g.MoveSequence(1, 0, 0))
+	require.True(t, g.Won)
+
+	clock.Advance(time.Hour)
+	assert.Equal(t, time.Minute, g.Elapsed(), "clock should stop once the game is won")
+
+	// undoing the winning move means play continues
+	require.NoError(t, g.Undo())
+	clock.Advance(time.Second)
+	assert.Equal(t, time.Minute+time.Second, g.Elapsed())
+}
+
+func TestClock_StopsWhenLost(t *testing.T) {
+	clock := newFakeClock()
+	g := &GameState{}
+	g.SetClock(clock)
+	// lone sevens can neither move nor accept anything here
+	for i := range TableauPiles {
+		g.Tableau.Piles[i] = newPile(makeCardInPile(deck.Clubs, deck.Seven, true))
+	}
+	g.Tableau.Piles[0] = newPile(
+		makeCardInPile(deck.Hearts, deck.Nine, false),
+		makeCardInPile(deck.Spades, deck.Four, true),
+	)
+	g.Tableau.Piles[1] = newPile(makeCardInPile(deck.Spades, deck.Five, true))
+
+	clock.Advance(time.Minute) // not counted, the clock starts with the first action
+	require.NoError(t, g.MoveSequence(0, 1, 1))
+	require.True(t, g.Lost, "the revealed nine has nowhere to go")
+
+	clock.Advance(time.Hour)
+	assert.Zero(t, g.Elapsed(), "clock should stop once the game is lost")
+}
+
+func TestCounters(t *testing.T) {
+	g := movableGame(newFakeClock())
+	g.Stock = make([]deck.Card, TableauPiles)
+
+	require.NoError(t, g.MoveSequence(0, 0, 1))
+	require.NoError(t, g.DealRow())
+	require.NoError(t, g.Undo())
+	assert.Error(t, g.MoveSequence(0, 0, 0), "failed moves are not counted")
+
+	assert.Equal(t, 1, g.Moves)
+	assert.Equal(t, 1, g.Deals, "undo does not rewind the counters")
+	assert.Equal(t, 1, g.Undos)
+
+	view := g.View()
+	assert.Equal(t, 1, view.Moves)
+	assert.Equal(t, 1, view.Deals)
+	assert.Equal(t, 1, view.Undos)
+}
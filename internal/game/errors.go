@@ -21,6 +21,14 @@ var (
 	ErrInvalidSequence         = errors.New("invalid move: sequence not ordered")
 	ErrDestinationNotAccepting = errors.New("invalid move: destination cannot accept")
 	ErrNoHistory               = errors.New("no moves to undo")
+	ErrNoRedo                  = errors.New("no moves to redo")
+	ErrCannotAutoComplete      = errors.New("the game can't be finished automatically")
+	ErrInvalidMoveNotation     = errors.New("invalid move notation: want src:start>dst or deal")
+)
+
+// persistence errors
+var (
+	ErrInvalidSave = errors.New("invalid save file")
 )
 
 // internal errors
@@ -40,6 +48,51 @@ func ErrFlipWithContext(err error) error {
 	return fmt.Errorf("%w: %v", ErrFlipFailed, err)
 }
 
+// errorCodes gives each engine error a stable identifier for clients outside Go
+var errorCodes = []struct {
+	err  error
+	code string
+}{
+	{ErrNotEnoughCards, "not_enough_cards"},
+	{ErrInsufficientStock, "insufficient_stock"},
+	{ErrInvalidSourceIndex, "invalid_source_index"},
+	{ErrInvalidDestinationIndex, "invalid_destination_index"},
+	{ErrSamePileMove, "same_pile_move"},
+	{ErrInvalidStartIndex, "invalid_start_index"},
+	{ErrNoCardsToMove, "no_cards_to_move"},
+	{ErrInvalidSequence, "invalid_sequence"},
+	{ErrDestinationNotAccepting, "destination_not_accepting"},
+	{ErrNoHistory, "no_history"},
+	{ErrNoRedo, "no_redo"},
+	{ErrCannotAutoComplete, "cannot_auto_complete"},
+	{ErrInvalidMoveNotation, "invalid_move_notation"},
+	{ErrInvalidSave, "invalid_save"},
+	{ErrSequenceMismatch, "internal"},
+	{ErrFlipFailed, "internal"},
+	{ErrRemoveCardsFailed, "internal"},
+}
+
+// ErrorCode returns a stable snake_case code for an engine error, for protocols and
+// logs where Go error values can't be compared. Errors from outside the engine give "".
+func ErrorCode(err error) string {
+	switch {
+	case err == nil:
+		return ""
+	case errors.As(err, &CardFaceDownError{}):
+		return "card_face_down"
+	case errors.As(err, &SaveVersionError{}):
+		return "unsupported_save_version"
+	case errors.As(err, &ViewSchemaError{}):
+		return "unsupported_view_schema"
+	}
+	for _, c := range errorCodes {
+		if errors.Is(err, c.err) {
+			return c.code
+		}
+	}
+	return ""
+}
+
 // typed errors
 
 type CardFaceDownError struct {
@@ -49,3 +102,29 @@ type CardFaceDownError struct {
 func (e CardFaceDownError) Error() string {
 	return fmt.Sprintf("card at position %d is face down", e.Index)
 }
+
+// SaveVersionError reports a save file whose schema version this build cannot read
+type SaveVersionError struct {
+	Version int
+}
+
+func (e SaveVersionError) Error() string {
+	if e.Newer() {
+		return fmt.Sprintf("save version %d is newer than this build reads (up to %d)", e.Version, SaveVersion)
+	}
+	return fmt.Sprintf("invalid save version %d", e.Version)
+}
+
+// Newer reports whether the save came from a later release, rather than being damaged
+func (e SaveVersionError) Newer() bool {
+	return e.Version > SaveVersion
+}
+
+// ViewSchemaError reports a JSON view whose schema version this build cannot read
+type ViewSchemaError struct {
+	Schema int
+}
+
+func (e ViewSchemaError) Error() string {
+	return fmt.Sprintf("unsupported view schema %d (this build reads up to %d)", e.Schema, ViewSchemaVersion)
+}
@@ -0,0 +1,35 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSaveVersionError_NamesNewerVersionsOnly(t *testing.T) {
+	assert.Contains(t, SaveVersionError{Version: SaveVersion + 1}.Error(), "newer")
+	assert.NotContains(t, SaveVersionError{Version: 0}.Error(), "newer")
+	assert.NotContains(t, SaveVersionError{Version: -3}.Error(), "newer")
+}
+
+func TestErrorCode(t *testing.T) {
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{err: nil, want: ""},
+		{err: ErrDestinationNotAccepting, want: "destination_not_accepting"},
+		{err: fmt.Errorf("wrapped: %w", ErrNoHistory), want: "no_history"},
+		{err: CardFaceDownError{Index: 2}, want: "card_face_down"},
+		{err: SaveVersionError{Version: 9}, want: "unsupported_save_version"},
+		{err: ErrFlipWithContext(errors.New("boom")), want: "internal"},
+		{err: errors.New("not ours"), want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.err), func(t *testing.T) {
+			assert.Equal(t, tt.want, ErrorCode(tt.err))
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package game
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/staylor11x/spider-solitaire/internal/deck"
+)
+
+// EventKind identifies what happened during an engine action
+type EventKind int
+
+const (
+	EventMoveApplied   EventKind = iota // a sequence moved between piles
+	EventCardRevealed                   // a face-down card was turned over
+	EventRunCompleted                   // a King->Ace run was removed from a pile
+	EventRowDealt                       // a row was dealt from the stock
+	EventUndoApplied                    // the last action was undone
+	EventGameWon                        // the final run was completed
+	EventGameLost                       // no moves remain
+	EventAutoCompleted                  // the moves that follow finish the game automatically
+)
+
+var eventKindNames = [...]string{
+	EventMoveApplied:   "MoveApplied",
+	EventCardRevealed:  "CardRevealed",
+	EventRunCompleted:  "RunCompleted",
+	EventRowDealt:      "RowDealt",
+	EventUndoApplied:   "UndoApplied",
+	EventGameWon:       "GameWon",
+	EventGameLost:      "GameLost",
+	EventAutoCompleted: "AutoCompleted",
+}
+
+func (k EventKind) String() string {
+	if k < 0 || int(k) >= len(eventKindNames) {
+		return fmt.Sprintf("EventKind(%d)", int(k))
+	}
+	return eventKindNames[k]
+}
+
+// Event is one side effect of an engine action. Only the fields relevant to the kind are set:
+//   - MoveApplied: Move and Count (cards moved)
+//   - CardRevealed: Pile and Card
+//   - RunCompleted: Pile and Card (the King at the head of the run, so its suit)
+//   - RowDealt: Move (always DealMove) and Count (cards dealt)
+//   - AutoCompleted: Count (moves that follow as part of the same action)
+type Event struct {
+	Kind  EventKind
+	Move  Move
+	Pile  int
+	Card  deck.Card
+	Count int
+}
+
+// String gives a short description for logs
+func (e Event) String() string {
+	switch e.Kind {
+	case EventMoveApplied:
+		return fmt.Sprintf("%s %s (%d cards)", e.Kind, e.Move, e.Count)
+	case EventCardRevealed:
+		return fmt.Sprintf("%s pile %d: %s", e.Kind, e.Pile, e.Card)
+	case EventRunCompleted:
+		return fmt.Sprintf("%s pile %d: %s", e.Kind, e.Pile, e.Card.Suit)
+	case EventRowDealt:
+		return fmt.Sprintf("%s (%d cards)", e.Kind, e.Count)
+	case EventAutoCompleted:
+		return fmt.Sprintf("%s (%d moves)", e.Kind, e.Count)
+	default:
+		return e.Kind.String()
+	}
+}
+
+// Observer receives the events of each engine action, in order, once the action has finished
+type Observer func(Event)
+
+type subscription struct {
+	id int
+	fn Observer
+}
+
+// Subscribe registers an observer for every later action and returns a function that removes it.
+// Observers are not carried over by Clone.
+func (g *GameState) Subscribe(o Observer) (unsubscribe func()) {
+	g.nextObserverID++
+	id := g.nextObserverID
+	g.observers = append(g.observers, subscription{id: id, fn: o})
+	return func() {
+		g.observers = slices.DeleteFunc(g.observers, func(s subscription) bool { return s.id == id })
+	}
+}
+
+// LastEvents returns the events produced by the most recent successful action.
+// A rejected move or deal leaves them unchanged.
+func (g *GameState) LastEvents() []Event {
+	return slices.Clone(g.events)
+}
+
+// beginAction starts a fresh event buffer; call it once an action has passed validation
+func (g *GameState) beginAction() {
+	g.events = nil
+}
+
+// emit records an event for the action in progress
+func (g *GameState) emit(e Event) {
+	g.events = append(g.events, e)
+}
+
+// publish delivers the current action's events to every observer
+func (g *GameState) publish() {
+	if len(g.observers) == 0 {
+		return
+	}
+	// copy so an observer can unsubscribe itself mid-delivery
+	observers := slices.Clone(g.observers)
+	for _, e := range g.events {
+		for _, s := range observers {
+			s.fn(e)
+		}
+	}
+}
+
+// revealTop flips the top card of a pile if it is face down, emitting CardRevealed
+func (g *GameState) revealTop(pileIdx int) error {
+	pile := &g.Tableau.Piles[pileIdx]
+	top, err := pile.TopCard()
+	if err != nil || top.FaceUp {
+		return nil // empty pile or nothing hidden - this is ok
+	}
+	return g.do(step{kind: stepFlip, pile: pileIdx})
+}
@@ -0,0 +1,127 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/staylor11x/spider-solitaire/internal/deck"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func kinds(events []Event) []EventKind {
+	out := make([]EventKind, len(events))
+	for i, e := range events {
+		out[i] = e.Kind
+	}
+	return out
+}
+
+func TestEvents_MoveRevealsCard(t *testing.T) {
+	g := &GameState{}
+	g.Tableau.Piles[0] = newPile(
+		makeCardInPile(deck.Hearts, deck.Nine, false),
+		makeCardInPile(deck.Spades, deck.Seven, true),
+	)
+	g.Tableau.Piles[1] = newPile(makeCardInPile(deck.Spades, deck.Eight, true))
+
+	require.NoError(t, g.MoveSequence(0, 1, 1))
+
+	assert.Equal(t, []Event{
+		{Kind: EventMoveApplied, Move: TableauMove(0, 1, 1), Count: 1},
+		{Kind: EventCardRevealed, Pile: 0, Card: deck.Card{Suit: deck.Hearts, Rank: deck.Nine}},
+	}, g.LastEvents())
+}
+
+func TestEvents_DealCompletesRun(t *testing.T) {
+	g := &GameState{Stock: make([]deck.Card, TableauPiles)}
+	g.Tableau.Piles[0].AddCards(newSequenceWithIgnoreRank(deck.Spades, deck.Ace))
+	g.Stock[TableauPiles-1] = deck.Card{Suit: deck.Spades, Rank: deck.Ace}
+	for i := range TableauPiles - 1 {
+		g.Stock[i] = deck.Card{Suit: deck.Hearts, Rank: deck.Two}
+	}
+
+	require.NoError(t, g.DealRow())
+
+	assert.Equal(t, []Event{
+		{Kind: EventRowDealt, Move: DealMove(), Count: TableauPiles},
+		{Kind: EventRunCompleted, Pile: 0, Card: deck.Card{Suit: deck.Spades, Rank: deck.King}},
+	}, g.LastEvents())
+}
+
+func TestEvents_GameWonAndUndo(t *testing.T) {
+	g := AlmostWon()
+
+	require.NoError(t, g.MoveSequence(1, 0, 0))
+	assert.Equal(t, []EventKind{EventMoveApplied, EventRunCompleted, EventGameWon}, kinds(g.LastEvents()))
+
+	require.NoError(t, g.Undo())
+	assert.Equal(t, []EventKind{EventUndoApplied}, kinds(g.LastEvents()))
+}
+
+func TestEvents_GameLost(t *testing.T) {
+	g := &GameState{}
+	for i := range TableauPiles {
+		g.Tableau.Piles[i] = newPile(makeCardInPile(deck.Clubs, deck.Seven, true))
+	}
+	g.Tableau.Piles[0] = newPile(
+		makeCardInPile(deck.Hearts, deck.Nine, false),
+		makeCardInPile(deck.Spades, deck.Four, true),
+	)
+	g.Tableau.Piles[1] = newPile(makeCardInPile(deck.Spades, deck.Five, true))
+
+	require.NoError(t, g.MoveSequence(0, 1, 1))
+	assert.Equal(t, []EventKind{EventMoveApplied, EventCardRevealed, EventGameLost}, kinds(g.LastEvents()))
+}
+
+func TestEvents_RejectedActionKeepsLastEvents(t *testing.T) {
+	g := &GameState{}
+	g.Tableau.Piles[0] = newPile(makeCardInPile(deck.Spades, deck.Seven, true))
+	g.Tableau.Piles[1] = newPile(makeCardInPile(deck.Spades, deck.Eight, true))
+	require.NoError(t, g.MoveSequence(0, 0, 1))
+	before := g.LastEvents()
+
+	var got []Event
+	g.Subscribe(func(e Event) { got = append(got, e) })
+
+	assert.Error(t, g.MoveSequence(1, 0, 1))
+	assert.ErrorIs(t, g.DealRow(), ErrInsufficientStock)
+	assert.Equal(t, before, g.LastEvents())
+	assert.Empty(t, got, "rejected actions should not notify observers")
+}
+
+func TestEvents_SubscribeAndUnsubscribe(t *testing.T) {
+	g, err := DealSeededGame(deck.OneSuit, 5)
+	require.NoError(t, err)
+
+	var first, second []EventKind
+	unsubscribe := g.Subscribe(func(e Event) { first = append(first, e.Kind) })
+	g.Subscribe(func(e Event) { second = append(second, e.Kind) })
+
+	require.NoError(t, g.DealRow())
+	assert.Equal(t, kinds(g.LastEvents()), first)
+	assert.Equal(t, first, second)
+
+	unsubscribe()
+	require.NoError(t, g.Undo())
+	assert.NotContains(t, first, EventUndoApplied, "unsubscribed observer should not be called")
+	assert.Contains(t, second, EventUndoApplied)
+}
+
+func TestEvents_CloneDropsObservers(t *testing.T) {
+	g, err := DealSeededGame(deck.OneSuit, 5)
+	require.NoError(t, err)
+
+	calls := 0
+	g.Subscribe(func(Event) { calls++ })
+
+	c := g.Clone()
+	require.NoError(t, c.DealRow())
+	assert.Zero(t, calls, "search on a clone must not reach the original's observers")
+}
+
+func TestEventString(t *testing.T) {
+	assert.Equal(t, "MoveApplied 3:5>7 (2 cards)", Event{Kind: EventMoveApplied, Move: TableauMove(3, 5, 7), Count: 2}.String())
+	assert.Equal(t, "RunCompleted pile 4: Spades", Event{Kind: EventRunCompleted, Pile: 4, Card: deck.Card{Suit: deck.Spades, Rank: deck.King}}.String())
+	assert.Equal(t, "GameWon", Event{Kind: EventGameWon}.String())
+	assert.Equal(t, "EventKind(42)", EventKind(42).String())
+}
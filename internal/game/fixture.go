@@ -0,0 +1,27 @@
+package game
+
+import "github.com/staylor11x/spider-solitaire/internal/deck"
+
+// AlmostWon returns a one-suit game a single move from winning, for tests in this and
+// other packages: seven runs of spades are completed, pile 0 holds the eighth from King
+// down to Two and the Ace of Spades lies alone on pile 1, so moving it across (1:0>0)
+// wins. No cards are face down, the stock is empty and the score is the classic start.
+func AlmostWon() *GameState {
+	g := &GameState{SuitCount: deck.OneSuit, Score: ClassicScoring{}.InitialScore()}
+	for range TotalRunsToWin - 1 {
+		g.Completed = append(g.Completed, CompletedRun(deck.Spades))
+	}
+	run := CompletedRun(deck.Spades)
+	g.Tableau.Piles[0].AddCards(run[:RunLength-1])
+	g.Tableau.Piles[1].AddCards(run[RunLength-1:])
+	return g
+}
+
+// CompletedRun returns a face-up run of one suit from King down to Ace
+func CompletedRun(s deck.Suit) []CardInPile {
+	run := make([]CardInPile, 0, RunLength)
+	for r := deck.King; r >= deck.Ace; r-- {
+		run = append(run, CardInPile{Card: deck.Card{Suit: s, Rank: r}, FaceUp: true})
+	}
+	return run
+}
@@ -2,6 +2,7 @@ package game
 
 import (
 	"slices"
+	"time"
 
 	"github.com/staylor11x/spider-solitaire/internal/deck"
 )
@@ -16,7 +17,6 @@ const (
 	FirstPileCount   = 4  // number of piles that get 6 cards
 	RunLength        = 13 // King to Ace
 	TotalRunsToWin   = 8
-	maxHistorySize   = 25
 )
 
 // GameState represents the complete state of a spider game
@@ -26,14 +26,42 @@ type GameState struct {
 	Completed [][]CardInPile
 	Won       bool
 	Lost      bool
-	history   []GameState
+	SuitCount deck.SuitCount // difficulty the deal was made with
+	Seed      uint64         // deal number, the same seed and suit count always give the same layout
+	Score     int
+	Moves     int           // sequence moves made, never rewound by undo
+	Deals     int           // rows dealt, never rewound by undo
+	Undos     int           // undos performed
+	scoring   ScoringPolicy // nil means ClassicScoring
+
+	// undo history, see history.go
+	history []command
+	redo    []command // undone actions, most recently undone last
+	pending *command  // the action being recorded
+
+	// play clock, see clock.go
+	clock        Clock
+	elapsed      time.Duration // time banked while the clock was running
+	runningSince time.Time     // zero when the clock is stopped
+	paused       bool
+
+	// event stream, see events.go
+	events         []Event // events of the most recent action
+	observers      []subscription
+	nextObserverID int
 }
 
-// DealInitialGame creates a new spider layout using two decks
+// DealInitialGame creates a new spider layout using two decks and a random deal number
 func DealInitialGame(suitCount deck.SuitCount) (*GameState, error) {
+	return DealSeededGame(suitCount, deck.RandomSeed())
+}
+
+// DealSeededGame creates the spider layout identified by seed.
+// Deals are reproducible: the same seed and suit count give the same tableau and stock order.
+func DealSeededGame(suitCount deck.SuitCount, seed uint64) (*GameState, error) {
 
 	d := deck.NewSpiderDeck(suitCount)
-	d.Shuffle()
+	d.ShuffleWith(deck.NewRNG(seed))
 
 	if d.Size() != TotalSpiderCards {
 		return nil, ErrNotEnoughCards
@@ -61,23 +89,42 @@ func DealInitialGame(suitCount deck.SuitCount) (*GameState, error) {
 	stock := d.DrawAll()
 
 	return &GameState{
-		Tableau: *t,
-		Stock:   stock,
+		Tableau:   *t,
+		Stock:     stock,
+		SuitCount: suitCount,
+		Seed:      seed,
+		Score:     ClassicScoring{}.InitialScore(),
 	}, nil
 }
 
+// Restart deals this game's layout again from its seed and suit count, as a new game with
+// a fresh score, clock and history. The scoring policy and clock source carry over.
+func (g *GameState) Restart() (*GameState, error) {
+	r, err := DealSeededGame(g.SuitCount, g.Seed)
+	if err != nil {
+		return nil, err
+	}
+	if g.scoring != nil {
+		r.SetScoringPolicy(g.scoring)
+	}
+	r.clock = g.clock
+	return r, nil
+}
+
 // DealRow deals one card face-up onto each tableau pile from the stock
 func (g *GameState) DealRow() error {
 
 	if !g.canDealRow() {
 		return ErrInsufficientStock
 	}
-	g.pushHistory()
+	g.beginAction()
+	defer g.publish()
+	g.beginCommand()
+	defer g.endCommand()
+	g.startClock()
 
-	for i := range TableauPiles {
-		card := g.Stock[len(g.Stock)-1] // take from the end
-		g.Stock = g.Stock[:len(g.Stock)-1]
-		g.Tableau.Piles[i].AddCard(card, true)
+	if err := g.do(step{kind: stepRowDealt}); err != nil {
+		return err
 	}
 	if err := g.checkCompletedRuns(); err != nil {
 		return err
@@ -96,26 +143,23 @@ func (g *GameState) canDealRow() bool {
 
 func (g *GameState) MoveSequence(srcIdx, startIdx, dstIdx int) error {
 
-	if err := g.validateMoveIndices(srcIdx, startIdx, dstIdx); err != nil {
-		return err
-	}
-
-	src := &g.Tableau.Piles[srcIdx]
-	dst := &g.Tableau.Piles[dstIdx]
-
-	sequence, err := g.validateMoveSequence(src, startIdx)
+	sequence, err := g.validateMove(srcIdx, startIdx, dstIdx)
 	if err != nil {
 		return err
 	}
 
-	if !dst.CanAccept(sequence) {
-		return ErrDestinationNotAccepting
-	}
-	g.pushHistory()
+	g.beginAction()
+	defer g.publish()
+	g.beginCommand()
+	defer g.endCommand()
+	g.startClock()
+	return g.playMove(srcIdx, startIdx, dstIdx, sequence)
+}
 
+// playMove makes a validated move as part of the action being recorded, counting and scoring it
+func (g *GameState) playMove(srcIdx, startIdx, dstIdx int, sequence []CardInPile) error {
 	// perform atomic move
-	err = g.executeMove(src, dst, startIdx, sequence)
-	if err != nil {
+	if err := g.executeMove(srcIdx, dstIdx, startIdx, sequence); err != nil {
 		return err
 	}
 
@@ -126,6 +170,23 @@ func (g *GameState) MoveSequence(srcIdx, startIdx, dstIdx int) error {
 	return nil
 }
 
+// validateMove runs every move check without mutating state, returning the sequence that would move
+func (g *GameState) validateMove(srcIdx, startIdx, dstIdx int) ([]CardInPile, error) {
+	if err := g.validateMoveIndices(srcIdx, startIdx, dstIdx); err != nil {
+		return nil, err
+	}
+
+	sequence, err := g.validateMoveSequence(&g.Tableau.Piles[srcIdx], startIdx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !g.Tableau.Piles[dstIdx].CanAccept(sequence) {
+		return nil, ErrDestinationNotAccepting
+	}
+	return sequence, nil
+}
+
 func (g *GameState) validateMoveIndices(srcIdx, startIdx, dstIdx int) error {
 
 	if srcIdx < 0 || srcIdx >= TableauPiles {
@@ -195,25 +256,19 @@ func isValidSequence(cards []CardInPile) bool {
 	return true
 }
 
-func (g *GameState) executeMove(src, dst *Pile, startIdx int, sequence []CardInPile) error {
+func (g *GameState) executeMove(srcIdx, dstIdx, startIdx int, sequence []CardInPile) error {
 
-	removedCards, err := src.RemoveCardsFrom(startIdx)
-	if err != nil {
-		return ErrRemoveCardsWithContext(err)
-	}
-
-	// Paranoid check: verify removed cards match expected sequence
-	if !sequenceEqual(removedCards, sequence) {
-		// critical error, restore the cards and fail
-		src.AddCards(removedCards)
+	// Paranoid check: verify the cards about to move match the expected sequence
+	if !sequenceEqual(g.Tableau.Piles[srcIdx].Cards()[startIdx:], sequence) {
 		return ErrSequenceMismatch
 	}
 
-	// add cards to destination
-	dst.AddCards(removedCards)
+	if err := g.do(step{kind: stepMove, pile: srcIdx, dst: dstIdx, count: len(sequence)}); err != nil {
+		return err
+	}
 
 	// flip top card of source if needed
-	if err := src.FlipTopCardIfFaceDown(); err != nil {
+	if err := g.revealTop(srcIdx); err != nil {
 		return ErrFlipWithContext(err)
 	}
 
@@ -242,14 +297,12 @@ func (g *GameState) checkCompletedRuns() error {
 		// look at the last 13 cards
 		last := pile.Cards()[pile.Size()-RunLength:]
 		if isValidRun(last) {
-			removed, err := pile.RemoveCardsFrom(pile.Size() - RunLength)
-			if err != nil {
-				return ErrRemoveCardsWithContext(err)
+			if err := g.do(step{kind: stepRunCollected, pile: i}); err != nil {
+				return err
 			}
-			g.Completed = append(g.Completed, removed)
 
 			// flip top card if needed
-			if err := pile.FlipTopCardIfFaceDown(); err != nil {
+			if err := g.revealTop(i); err != nil {
 				return ErrFlipWithContext(err)
 			}
 		}
@@ -298,6 +351,8 @@ func (g *GameState) checkWinCondition() {
 
 	if len(g.Completed) >= TotalRunsToWin {
 		g.Won = true
+		g.stopClock()
+		g.emit(Event{Kind: EventGameWon})
 	}
 }
 
@@ -316,14 +371,18 @@ func (g *GameState) checkLossCondition() {
 		return
 	}
 	g.Lost = true
+	g.stopClock()
+	g.emit(Event{Kind: EventGameLost})
 }
 
-// snapshot creates a deep copy of the current GameState for undo history
+// snapshot creates a deep copy of the current position, without history or play statistics
 func (g *GameState) snapshot() GameState {
 	snap := GameState{
-		Won:   g.Won,
-		Lost:  g.Lost,
-		Stock: make([]deck.Card, len(g.Stock)),
+		Won:     g.Won,
+		Lost:    g.Lost,
+		Score:   g.Score,
+		scoring: g.scoring,
+		Stock:   make([]deck.Card, len(g.Stock)),
 	}
 	copy(snap.Stock, g.Stock)
 
@@ -339,37 +398,18 @@ func (g *GameState) snapshot() GameState {
 		copy(snap.Completed[i], run)
 	}
 
-	// don't copy history itself (would cause exponential memory growth!)
 	return snap
 }
 
-// push history saves the current state before an action
-func (g *GameState) pushHistory() {
-	snap := g.snapshot()
-	g.history = append(g.history, snap)
-
-	// Enforce size limit (FIFO - remove oldest object if needed)
-	if len(g.history) > maxHistorySize {
-		g.history = g.history[1:]
-	}
-}
-
-func (g *GameState) Undo() error {
-	if len(g.history) == 0 {
-		return ErrNoHistory
-	}
-
-	// Pop the last state from history
-	lastIdx := len(g.history) - 1
-	previous := g.history[lastIdx]
-	g.history = g.history[:lastIdx]
-
-	// restore the previous state (but preserve remaining history)
-	g.Tableau = previous.Tableau
-	g.Stock = previous.Stock
-	g.Completed = previous.Completed
-	g.Won = previous.Won
-	g.Lost = previous.Lost
-
-	return nil
+// Clone returns an independent deep copy of the game without its undo history.
+// Search code (solvers, bots) uses it to try moves without touching the real game.
+func (g *GameState) Clone() *GameState {
+	c := g.snapshot()
+	c.SuitCount = g.SuitCount
+	c.Seed = g.Seed
+	c.Moves, c.Deals, c.Undos = g.Moves, g.Deals, g.Undos
+	c.clock = g.clock
+	c.elapsed = g.Elapsed() // the copy's clock starts stopped
+	c.paused = g.paused
+	return &c
 }
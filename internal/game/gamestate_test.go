@@ -5,6 +5,7 @@ import (
 
 	"github.com/staylor11x/spider-solitaire/internal/deck"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // Helper Functions
@@ -25,18 +26,6 @@ func makeCardInPile(s deck.Suit, r deck.Rank, faceUp bool) CardInPile {
 	}
 }
 
-// newSequence is a method that can be used to build a full completed sequence
-func newSequence(s deck.Suit) []CardInPile {
-	seq := make([]CardInPile, 0, 13)
-	for r := deck.King; r >= deck.Ace; r-- {
-		seq = append(seq, CardInPile{
-			Card:   deck.Card{Suit: s, Rank: r},
-			FaceUp: true,
-		})
-	}
-	return seq
-}
-
 // newSequenceWithIgnoreRank is a method that can be used to build a sequence with a card missing
 func newSequenceWithIgnoreRank(s deck.Suit, rankToIgnore deck.Rank) []CardInPile {
 	seq := make([]CardInPile, 0, 13)
@@ -138,7 +127,7 @@ func TestCheckWinCondition(t *testing.T) {
 			g := &GameState{}
 
 			for i := 0; i < tt.completedRuns; i++ {
-				g.Completed = append(g.Completed, newSequence(deck.Spades))
+				g.Completed = append(g.Completed, CompletedRun(deck.Spades))
 			}
 			g.checkWinCondition()
 
@@ -171,6 +160,60 @@ func TestDealInitialGame(t *testing.T) {
 	assert.Len(t, state.Stock, 50)
 }
 
+func TestDealSeededGame_IsReproducible(t *testing.T) {
+	for _, suits := range []deck.SuitCount{deck.OneSuit, deck.TwoSuits, deck.FourSuits} {
+		a, err := DealSeededGame(suits, 42)
+		assert.NoError(t, err)
+		b, err := DealSeededGame(suits, 42)
+		assert.NoError(t, err)
+
+		assert.Equal(t, a.Tableau, b.Tableau, "same seed should give the same tableau")
+		assert.Equal(t, a.Stock, b.Stock, "same seed should give the same stock order")
+		assert.Equal(t, uint64(42), a.Seed)
+		assert.Equal(t, suits, a.SuitCount)
+	}
+}
+
+func TestDealSeededGame_DifferentSeedsDiffer(t *testing.T) {
+	a, err := DealSeededGame(deck.FourSuits, 1)
+	assert.NoError(t, err)
+	b, err := DealSeededGame(deck.FourSuits, 2)
+	assert.NoError(t, err)
+
+	assert.NotEqual(t, a.Stock, b.Stock)
+}
+
+func TestDealInitialGame_RecordsSeed(t *testing.T) {
+	g, err := DealInitialGame(deck.TwoSuits)
+	assert.NoError(t, err)
+
+	// replaying the recorded deal number must rebuild the same layout
+	replay, err := DealSeededGame(g.SuitCount, g.Seed)
+	assert.NoError(t, err)
+	assert.Equal(t, g.Tableau, replay.Tableau)
+	assert.Equal(t, g.Stock, replay.Stock)
+}
+
+func TestRestart_RedealsSameLayout(t *testing.T) {
+	g, err := DealSeededGame(deck.TwoSuits, 42)
+	require.NoError(t, err)
+	initial := g.View()
+	g.SetScoringPolicy(flatScoring{})
+	require.NoError(t, g.DealRow())
+
+	r, err := g.Restart()
+	require.NoError(t, err)
+	assert.Equal(t, initial.Tableau, r.View().Tableau)
+	assert.Equal(t, initial.StockCount, r.View().StockCount)
+	assert.Equal(t, uint64(42), r.Seed)
+	assert.Zero(t, r.Deals)
+	assert.Zero(t, r.Score, "the scoring policy carries over")
+	assert.ErrorIs(t, r.Undo(), ErrNoHistory)
+
+	_, err = (&GameState{}).Restart()
+	assert.ErrorIs(t, err, ErrNotEnoughCards, "a game without a suit count can't be redealt")
+}
+
 func TestDealRow(t *testing.T) {
 	state, err := DealInitialGame(deck.FourSuits)
 	assert.NoError(t, err)
@@ -410,7 +453,7 @@ func TestDealRow_DoesNotCompleteRunWithFaceDownCard(t *testing.T) {
 func TestCheckCompletedRuns_IsIdempotent(t *testing.T) {
 	g := &GameState{Tableau: Tableau{Piles: [10]Pile{}}}
 
-	run := newSequence(deck.Spades)
+	run := CompletedRun(deck.Spades)
 	g.Tableau.Piles[0].AddCards(run)
 
 	g.checkCompletedRuns()
@@ -426,7 +469,7 @@ func TestRunCompletion_ConservesTotalCards(t *testing.T) {
 
 	total := 0
 
-	run := newSequence(deck.Spades)
+	run := CompletedRun(deck.Spades)
 	g.Tableau.Piles[0].AddCards(run)
 
 	for _, pile := range g.Tableau.Piles {
@@ -472,7 +515,7 @@ func TestGame_WinTriggeredByMoveSequence(t *testing.T) {
 
 	// Preload 7 completed runs
 	for range 7 {
-		g.Completed = append(g.Completed, newSequence(deck.Hearts))
+		g.Completed = append(g.Completed, CompletedRun(deck.Hearts))
 	}
 
 	// build an almost complete run (missing ace)
@@ -493,7 +536,7 @@ func TestGame_WinTriggeredByDealRow(t *testing.T) {
 
 	// preload 7 completed runs
 	for range 7 {
-		g.Completed = append(g.Completed, newSequence(deck.Clubs))
+		g.Completed = append(g.Completed, CompletedRun(deck.Clubs))
 	}
 
 	// carefully construct the tableau so that dealing a row completed a run
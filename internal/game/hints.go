@@ -0,0 +1,173 @@
+package game
+
+import (
+	"slices"
+
+	"github.com/staylor11x/spider-solitaire/internal/deck"
+)
+
+// Hint scores used to rank suggestions. Anything above zero is "productive".
+const (
+	hintRevealScore    = 100 // turning over a face-down card is almost always best
+	hintEmptyPileScore = 60  // an empty pile is the most flexible resource in spider
+	hintSameSuitScore  = 50  // same-suit runs can later move together and complete
+	hintStackScore     = 10  // plain stacking on a higher card
+	hintToEmptyScore   = -20 // spending an empty pile without gaining anything
+)
+
+// Hint is a suggested move with the score and reason it was ranked by
+type Hint struct {
+	Move   Move
+	Score  int
+	Reason string
+}
+
+// Hints returns ranked suggestions for the current position (best first).
+// An empty result means the position is stuck.
+func (g *GameState) Hints() []Hint {
+	return RankMoves(g.View(), g.LegalMoves())
+}
+
+// RankMoves orders moves from most to least promising.
+//
+// Only visible information in the view is used, so bots that must not see
+// face-down cards can rank moves too. Productive moves come first, then the
+// deal, then neutral moves; pointless shuffles that leave the position
+// effectively unchanged are dropped.
+func RankMoves(view GameViewDTO, moves []Move) []Hint {
+	piles := make([][]CardInPile, len(view.Tableau))
+	for i, p := range view.Tableau {
+		piles[i] = make([]CardInPile, len(p.Cards))
+		for j, c := range p.Cards {
+			piles[i][j] = CardInPile{Card: deck.Card{Suit: deck.Suit(c.Suit), Rank: deck.Rank(c.Rank)}, FaceUp: c.FaceUp}
+		}
+	}
+	return rankMoves(piles, moves)
+}
+
+// rankMoves is RankMoves over the tableau's cards; face-down cards are never looked at
+func rankMoves(piles [][]CardInPile, moves []Move) []Hint {
+	var productive, neutral []Hint
+	var deal *Hint
+
+	for _, m := range moves {
+		if m.Kind == MoveDeal {
+			deal = &Hint{Move: m, Reason: "deal a new row"}
+			continue
+		}
+		h, ok := rankTableauMove(piles, m)
+		if !ok {
+			continue
+		}
+		if h.Score > 0 {
+			productive = append(productive, h)
+		} else {
+			neutral = append(neutral, h)
+		}
+	}
+
+	byScore := func(a, b Hint) int { return b.Score - a.Score }
+	slices.SortStableFunc(productive, byScore)
+	slices.SortStableFunc(neutral, byScore)
+
+	hints := productive
+	if deal != nil {
+		hints = append(hints, *deal)
+	}
+	return append(hints, neutral...)
+}
+
+// OrderedMoves returns the legal moves worth searching, most promising first, ranked
+// the way RankMoves ranks them. Moves the ranking considers pointless are kept at the
+// end rather than dropped, except those that can't change anything: a whole pile moved
+// to an empty pile, and a run moved off one card onto another of the same rank and
+// suit without turning a card over.
+func (g *GameState) OrderedMoves() []Move {
+	piles := make([][]CardInPile, len(g.Tableau.Piles))
+	for i := range g.Tableau.Piles {
+		piles[i] = g.Tableau.Piles[i].cards
+	}
+	legal := g.LegalMoves()
+	out := make([]Move, 0, len(legal))
+	for _, h := range rankMoves(piles, legal) {
+		out = append(out, h.Move)
+	}
+	for _, m := range legal {
+		if !slices.Contains(out, m) && !changesNothing(piles, m) {
+			out = append(out, m)
+		}
+	}
+	return out
+}
+
+// changesNothing reports whether a legal sequence move only swaps the position for an
+// equivalent one: the whole pile onto an empty pile, or a run from its face-up parent
+// onto an identical card.
+func changesNothing(piles [][]CardInPile, m Move) bool {
+	if m.Kind != MoveTableau {
+		return false
+	}
+	src, dst := piles[m.Src], piles[m.Dst]
+	if len(dst) == 0 {
+		return m.Start == 0
+	}
+	return m.Start > 0 && src[m.Start-1].FaceUp && src[m.Start-1].Card == dst[len(dst)-1].Card
+}
+
+// rankTableauMove scores a single sequence move; ok is false for pointless shuffles
+func rankTableauMove(piles [][]CardInPile, m Move) (Hint, bool) {
+	if m.Src < 0 || m.Src >= len(piles) || m.Dst < 0 || m.Dst >= len(piles) {
+		return Hint{}, false
+	}
+	src := piles[m.Src]
+	dst := piles[m.Dst]
+	if m.Start < 0 || m.Start >= len(src) {
+		return Hint{}, false
+	}
+	moving := src[m.Start].Card
+
+	var parent *deck.Card // face-up card the sequence currently sits on, if any
+	if m.Start > 0 && src[m.Start-1].FaceUp {
+		parent = &src[m.Start-1].Card
+	}
+
+	if len(dst) == 0 {
+		switch {
+		case m.Start == 0:
+			return Hint{}, false // whole pile to an empty pile changes nothing
+		case parent == nil:
+			return Hint{Move: m, Score: hintRevealScore + hintToEmptyScore, Reason: "reveals a face-down card"}, true
+		case parent.Rank == moving.Rank+1:
+			return Hint{}, false // already sitting on a legal card, spending the gap gains nothing
+		default:
+			return Hint{Move: m, Score: hintToEmptyScore, Reason: "moves to an empty pile"}, true
+		}
+	}
+
+	top := dst[len(dst)-1].Card
+	sameSuit := top.Suit == moving.Suit
+
+	switch {
+	case m.Start == 0:
+		return Hint{Move: m, Score: hintEmptyPileScore + suitBonus(sameSuit), Reason: "empties a pile"}, true
+	case parent == nil:
+		return Hint{Move: m, Score: hintRevealScore + suitBonus(sameSuit), Reason: "reveals a face-down card"}, true
+	case parent.Rank == moving.Rank+1:
+		// only worth it when it trades a cross-suit parent for a same-suit one
+		if sameSuit && parent.Suit != moving.Suit {
+			return Hint{Move: m, Score: hintSameSuitScore, Reason: "builds a same-suit run"}, true
+		}
+		return Hint{}, false
+	case sameSuit:
+		return Hint{Move: m, Score: hintSameSuitScore, Reason: "builds a same-suit run"}, true
+	default:
+		return Hint{Move: m, Score: hintStackScore, Reason: "stacks on a higher card"}, true
+	}
+}
+
+func suitBonus(sameSuit bool) int {
+	if sameSuit {
+		return hintSameSuitScore
+	}
+	return 0
+}
@@ -0,0 +1,164 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/staylor11x/spider-solitaire/internal/deck"
+	"github.com/stretchr/testify/assert"
+)
+
+// blockedGame returns a game whose piles all hold a lone face-down ace,
+// so only the piles a test sets up can produce moves.
+func blockedGame() *GameState {
+	g := &GameState{}
+	for i := range TableauPiles {
+		g.Tableau.Piles[i] = newPile(makeCardInPile(deck.Spades, deck.Ace, false))
+	}
+	return g
+}
+
+func TestHints_Ranking(t *testing.T) {
+	tests := []struct {
+		name       string
+		setup      func(g *GameState)
+		wantFirst  Move
+		wantReason string
+	}{
+		{
+			name: "revealing a face-down card beats stacking",
+			setup: func(g *GameState) {
+				g.Tableau.Piles[0] = newPile(
+					makeCardInPile(deck.Clubs, deck.Four, false),
+					makeCardInPile(deck.Hearts, deck.Nine, true),
+				)
+				g.Tableau.Piles[1] = newPile(makeCardInPile(deck.Spades, deck.Ten, true))
+				g.Tableau.Piles[2] = newPile(
+					makeCardInPile(deck.Spades, deck.Jack, true),
+					makeCardInPile(deck.Diamonds, deck.Eight, true),
+				)
+			},
+			wantFirst:  TableauMove(0, 1, 1),
+			wantReason: "reveals a face-down card",
+		},
+		{
+			name: "same-suit build beats cross-suit stacking",
+			setup: func(g *GameState) {
+				g.Tableau.Piles[0] = newPile(
+					makeCardInPile(deck.Clubs, deck.Two, true),
+					makeCardInPile(deck.Hearts, deck.Nine, true),
+				)
+				g.Tableau.Piles[1] = newPile(makeCardInPile(deck.Spades, deck.Ten, true))
+				g.Tableau.Piles[2] = newPile(makeCardInPile(deck.Hearts, deck.Ten, true))
+			},
+			wantFirst:  TableauMove(0, 1, 2),
+			wantReason: "builds a same-suit run",
+		},
+		{
+			name: "emptying a pile",
+			setup: func(g *GameState) {
+				g.Tableau.Piles[0] = newPile(makeCardInPile(deck.Hearts, deck.Nine, true))
+				g.Tableau.Piles[1] = newPile(makeCardInPile(deck.Spades, deck.Ten, true))
+			},
+			wantFirst:  TableauMove(0, 0, 1),
+			wantReason: "empties a pile",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := blockedGame()
+			tt.setup(g)
+
+			hints := g.Hints()
+			if assert.NotEmpty(t, hints) {
+				assert.Equal(t, tt.wantFirst, hints[0].Move)
+				assert.Equal(t, tt.wantReason, hints[0].Reason)
+			}
+		})
+	}
+}
+
+func TestHints_DropsPointlessShuffles(t *testing.T) {
+	g := blockedGame()
+	// 9H already sits on 10S; moving it to 10C gains nothing
+	g.Tableau.Piles[0] = newPile(
+		makeCardInPile(deck.Spades, deck.Ten, true),
+		makeCardInPile(deck.Hearts, deck.Nine, true),
+	)
+	g.Tableau.Piles[1] = newPile(makeCardInPile(deck.Clubs, deck.Ten, true))
+
+	for _, h := range g.Hints() {
+		assert.NotEqual(t, TableauMove(0, 1, 1), h.Move)
+	}
+}
+
+func TestOrderedMoves_KeepsPointlessMovesLast(t *testing.T) {
+	g := blockedGame()
+	g.Tableau.Piles[0] = newPile(
+		makeCardInPile(deck.Spades, deck.Ten, true),
+		makeCardInPile(deck.Hearts, deck.Nine, true),
+	)
+	g.Tableau.Piles[1] = newPile(makeCardInPile(deck.Clubs, deck.Ten, true))
+	g.Tableau.Piles[2] = newPile(makeCardInPile(deck.Clubs, deck.Jack, true))
+
+	moves := g.OrderedMoves()
+	assert.ElementsMatch(t, g.LegalMoves(), moves, "nothing is dropped")
+	assert.Equal(t, TableauMove(1, 0, 2), moves[0], "the hint ranker's choice comes first")
+	assert.Equal(t, TableauMove(0, 1, 1), moves[len(moves)-1], "the shuffle the hints drop comes last")
+}
+
+func TestOrderedMoves_PrunesMovesThatChangeNothing(t *testing.T) {
+	g := blockedGame()
+	g.Tableau.Piles[0] = newPile(
+		makeCardInPile(deck.Spades, deck.Ten, true),
+		makeCardInPile(deck.Hearts, deck.Nine, true),
+	)
+	g.Tableau.Piles[1] = newPile(makeCardInPile(deck.Spades, deck.Ten, true))
+	g.Tableau.Piles[2] = newPile(
+		makeCardInPile(deck.Clubs, deck.Four, false),
+		makeCardInPile(deck.Hearts, deck.Nine, true),
+	)
+	g.Tableau.Piles[3] = newPile(makeCardInPile(deck.Diamonds, deck.Eight, true))
+	g.Tableau.Piles[4] = Pile{}
+
+	moves := g.OrderedMoves()
+	assert.NotContains(t, moves, TableauMove(0, 1, 1), "9H onto an identical 10S")
+	assert.NotContains(t, moves, TableauMove(3, 0, 4), "a whole pile to an empty pile")
+	assert.Contains(t, moves, TableauMove(2, 1, 1), "the same card onto 10S from a face-down card reveals it")
+	assert.Contains(t, moves, TableauMove(0, 1, 4), "a run to an empty pile frees its parent")
+}
+
+func TestHints_DropsWholePileToEmptyPile(t *testing.T) {
+	g := blockedGame()
+	g.Tableau.Piles[0] = newPile(makeCardInPile(deck.Hearts, deck.Nine, true))
+	g.Tableau.Piles[1] = Pile{}
+
+	assert.Empty(t, g.Hints())
+}
+
+func TestHints_SuggestsDealWhenNothingProductive(t *testing.T) {
+	g := blockedGame()
+	g.Stock = make([]deck.Card, TableauPiles)
+
+	hints := g.Hints()
+	if assert.Len(t, hints, 1) {
+		assert.Equal(t, DealMove(), hints[0].Move)
+	}
+}
+
+func TestHints_DealRanksAfterProductiveMoves(t *testing.T) {
+	g := blockedGame()
+	g.Stock = make([]deck.Card, TableauPiles)
+	g.Tableau.Piles[0] = newPile(makeCardInPile(deck.Hearts, deck.Nine, true))
+	g.Tableau.Piles[1] = newPile(makeCardInPile(deck.Spades, deck.Ten, true))
+
+	hints := g.Hints()
+	if assert.Len(t, hints, 2) {
+		assert.Equal(t, TableauMove(0, 0, 1), hints[0].Move)
+		assert.Equal(t, DealMove(), hints[1].Move)
+	}
+}
+
+func TestHints_StuckPositionHasNoHints(t *testing.T) {
+	assert.Empty(t, blockedGame().Hints())
+}
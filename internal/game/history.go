@@ -0,0 +1,239 @@
+package game
+
+// Undo history is a list of commands, one per action. A command records the steps the
+// action made to the layout rather than a copy of the position, so history grows with
+// the number of moves instead of the size of the game and is never trimmed. Undo walks
+// a command's steps backwards; redo plays them forwards again through the same code the
+// original action used.
+
+// stepKind identifies one reversible change to the layout
+type stepKind int
+
+const (
+	stepMove         stepKind = iota // count cards moved from the top of pile onto dst
+	stepFlip                         // the face-down top card of pile turned face up
+	stepRunCollected                 // the top RunLength cards of pile moved to Completed
+	stepRowDealt                     // one card from the end of the stock dealt onto each pile
+)
+
+var stepKindNames = [...]string{
+	stepMove:         "move",
+	stepFlip:         "flip",
+	stepRunCollected: "run",
+	stepRowDealt:     "deal",
+}
+
+// step is one change made by an action. Only the fields relevant to the kind are set.
+type step struct {
+	kind  stepKind
+	pile  int
+	dst   int
+	count int
+}
+
+// command is one undoable action: its steps in order, and the score and result of the
+// position before it, which undo restores. auto marks an auto-complete, which redo
+// announces as one.
+type command struct {
+	steps []step
+	score int
+	won   bool
+	lost  bool
+	auto  bool
+}
+
+// beginCommand starts recording the steps of an action; call it once the action has passed validation
+func (g *GameState) beginCommand() {
+	g.pending = &command{score: g.Score, won: g.Won, lost: g.Lost}
+}
+
+// endCommand adds the recorded action to the history. A new action replaces anything that could be redone.
+func (g *GameState) endCommand() {
+	g.history = append(g.history, *g.pending)
+	g.pending = nil
+	g.redo = nil
+}
+
+// do applies a step, counts and scores it, records it on the action in progress and
+// emits its event. Every score is applied here, so redo scores in the same order as the
+// original action even under a policy where the order matters.
+func (g *GameState) do(s step) error {
+	switch s.kind {
+	case stepMove:
+		src := &g.Tableau.Piles[s.pile]
+		start := src.Size() - s.count
+		cards, err := src.RemoveCardsFrom(start)
+		if err != nil {
+			return ErrRemoveCardsWithContext(err)
+		}
+		g.Tableau.Piles[s.dst].AddCards(cards)
+		g.Moves++
+		g.applyScore(ScoreMove)
+		g.emit(Event{Kind: EventMoveApplied, Move: TableauMove(s.pile, start, s.dst), Count: s.count})
+
+	case stepFlip:
+		pile := &g.Tableau.Piles[s.pile]
+		top, err := pile.TopCard()
+		if err != nil {
+			return ErrFlipWithContext(err)
+		}
+		if err := pile.showTop(); err != nil {
+			return ErrFlipWithContext(err)
+		}
+		g.emit(Event{Kind: EventCardRevealed, Pile: s.pile, Card: top.Card})
+
+	case stepRunCollected:
+		pile := &g.Tableau.Piles[s.pile]
+		removed, err := pile.RemoveCardsFrom(pile.Size() - RunLength)
+		if err != nil {
+			return ErrRemoveCardsWithContext(err)
+		}
+		g.Completed = append(g.Completed, removed)
+		g.applyScore(ScoreRunCompleted)
+		g.emit(Event{Kind: EventRunCompleted, Pile: s.pile, Card: removed[0].Card})
+
+	case stepRowDealt:
+		if !g.canDealRow() {
+			return ErrInsufficientStock
+		}
+		for i := range TableauPiles {
+			card := g.Stock[len(g.Stock)-1] // take from the end
+			g.Stock = g.Stock[:len(g.Stock)-1]
+			g.Tableau.Piles[i].AddCard(card, true)
+		}
+		g.Deals++
+		g.applyScore(ScoreDeal)
+		g.emit(Event{Kind: EventRowDealt, Move: DealMove(), Count: TableauPiles})
+	}
+
+	if g.pending != nil {
+		g.pending.steps = append(g.pending.steps, s)
+	}
+	return nil
+}
+
+// undo reverses a step made by do
+func (g *GameState) undo(s step) error {
+	switch s.kind {
+	case stepMove:
+		dst := &g.Tableau.Piles[s.dst]
+		cards, err := dst.RemoveCardsFrom(dst.Size() - s.count)
+		if err != nil {
+			return ErrRemoveCardsWithContext(err)
+		}
+		g.Tableau.Piles[s.pile].AddCards(cards)
+
+	case stepFlip:
+		if err := g.Tableau.Piles[s.pile].hideTop(); err != nil {
+			return ErrFlipWithContext(err)
+		}
+
+	case stepRunCollected:
+		if len(g.Completed) == 0 {
+			return ErrRemoveCardsWithContext(ErrNoCardsToMove)
+		}
+		last := len(g.Completed) - 1
+		g.Tableau.Piles[s.pile].AddCards(g.Completed[last])
+		g.Completed = g.Completed[:last]
+
+	case stepRowDealt:
+		// the last pile got the deepest card, so it goes back first
+		for i := TableauPiles - 1; i >= 0; i-- {
+			pile := &g.Tableau.Piles[i]
+			cards, err := pile.RemoveCardsFrom(pile.Size() - 1)
+			if err != nil {
+				return ErrRemoveCardsWithContext(err)
+			}
+			g.Stock = append(g.Stock, cards[0].Card)
+		}
+	}
+	return nil
+}
+
+// Undo takes back the last move or deal, along with any flips and completed runs it caused.
+// There is no limit on how far back undo goes.
+func (g *GameState) Undo() error {
+	if len(g.history) == 0 {
+		return ErrNoHistory
+	}
+	g.beginAction()
+	defer g.publish()
+
+	last := len(g.history) - 1
+	c := g.history[last]
+	g.history = g.history[:last]
+	for i := len(c.steps) - 1; i >= 0; i-- {
+		if err := g.undo(c.steps[i]); err != nil {
+			return err
+		}
+	}
+	g.redo = append(g.redo, c)
+	g.Won = c.won
+	g.Lost = c.lost
+
+	// undo gives back the score of the restored position, but still costs like a move
+	g.Score = c.score
+	g.applyScore(ScoreUndo)
+	g.Undos++
+	g.emit(Event{Kind: EventUndoApplied})
+
+	// undoing out of a won or lost position means play continues
+	g.startClock()
+
+	return nil
+}
+
+// Redo plays the last undone action again. It counts and scores like making that move,
+// deal or auto-complete afresh, and emits the same events, so observers can't tell the two apart.
+// Any new move or deal clears what can be redone.
+func (g *GameState) Redo() error {
+	if len(g.redo) == 0 {
+		return ErrNoRedo
+	}
+	g.beginAction()
+	defer g.publish()
+	g.startClock()
+
+	last := len(g.redo) - 1
+	c := g.redo[last]
+	g.redo = g.redo[:last]
+	c.score, c.won, c.lost = g.Score, g.Won, g.Lost
+
+	// an auto-complete is announced like the original, with the number of moves that follow
+	if c.auto {
+		moves := 0
+		for _, s := range c.steps {
+			if s.kind == stepMove {
+				moves++
+			}
+		}
+		g.emit(Event{Kind: EventAutoCompleted, Count: moves})
+	}
+	for _, s := range c.steps {
+		if err := g.do(s); err != nil {
+			return err
+		}
+	}
+	g.history = append(g.history, c)
+
+	g.checkWinCondition()
+	if len(g.Stock) == 0 {
+		g.checkLossCondition()
+	}
+	return nil
+}
+
+// Rewind undoes every action back to the start of the history, one Undo at a time, so it
+// scores and emits events like that many undos. The undone actions are kept for Redo, so
+// the game can be stepped forward again move by move.
+func (g *GameState) Rewind() error {
+	if len(g.history) == 0 {
+		return ErrNoHistory
+	}
+	for len(g.history) > 0 {
+		if err := g.Undo(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
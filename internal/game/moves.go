@@ -0,0 +1,111 @@
+package game
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// MoveKind distinguishes moving cards between piles from dealing a row
+type MoveKind int
+
+const (
+	MoveTableau MoveKind = iota // move a sequence from one pile to another
+	MoveDeal                    // deal one row from the stock
+)
+
+// Move is a single player action. For MoveDeal the pile fields are unused.
+type Move struct {
+	Kind  MoveKind
+	Src   int // source pile index
+	Start int // index within the source pile of the first card to move
+	Dst   int // destination pile index
+}
+
+// TableauMove builds a move of the cards from start onwards in pile src onto pile dst
+func TableauMove(src, start, dst int) Move {
+	return Move{Kind: MoveTableau, Src: src, Start: start, Dst: dst}
+}
+
+// DealMove builds the deal-a-row move
+func DealMove() Move {
+	return Move{Kind: MoveDeal}
+}
+
+// String renders the move as "src:start>dst" or "deal"
+func (m Move) String() string {
+	if m.Kind == MoveDeal {
+		return "deal"
+	}
+	return fmt.Sprintf("%d:%d>%d", m.Src, m.Start, m.Dst)
+}
+
+// ParseMove reads the notation produced by Move.String. It only checks the syntax;
+// whether the move is legal depends on the game it is applied to.
+func ParseMove(s string) (Move, error) {
+	s = strings.TrimSpace(s)
+	if s == "deal" {
+		return DealMove(), nil
+	}
+	from, dst, ok := strings.Cut(s, ">")
+	if !ok {
+		return Move{}, ErrInvalidMoveNotation
+	}
+	src, start, ok := strings.Cut(from, ":")
+	if !ok {
+		return Move{}, ErrInvalidMoveNotation
+	}
+	var idx [3]int
+	for i, part := range []string{src, start, dst} {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return Move{}, ErrInvalidMoveNotation
+		}
+		idx[i] = n
+	}
+	return TableauMove(idx[0], idx[1], idx[2]), nil
+}
+
+// CanMove reports whether MoveSequence(srcIdx, startIdx, dstIdx) would succeed,
+// returning the error it would fail with. It never mutates the game.
+func (g *GameState) CanMove(srcIdx, startIdx, dstIdx int) error {
+	_, err := g.validateMove(srcIdx, startIdx, dstIdx)
+	return err
+}
+
+// LegalMoves lists every action currently allowed: each sequence move in
+// source, start, destination order, followed by a deal when the stock allows it.
+func (g *GameState) LegalMoves() []Move {
+	var moves []Move
+
+	for srcIdx := range g.Tableau.Piles {
+		src := &g.Tableau.Piles[srcIdx]
+		suffix := movableSuffix(src.cards)
+		firstStart := src.Size() - len(suffix)
+
+		for i := range suffix {
+			seq := suffix[i:]
+			for dstIdx := range g.Tableau.Piles {
+				if dstIdx == srcIdx {
+					continue
+				}
+				if g.Tableau.Piles[dstIdx].CanAccept(seq) {
+					moves = append(moves, TableauMove(srcIdx, firstStart+i, dstIdx))
+				}
+			}
+		}
+	}
+
+	if g.canDealRow() {
+		moves = append(moves, DealMove())
+	}
+	return moves
+}
+
+// Apply performs the given move through MoveSequence or DealRow
+func (g *GameState) Apply(m Move) error {
+	if m.Kind == MoveDeal {
+		return g.DealRow()
+	}
+	return g.MoveSequence(m.Src, m.Start, m.Dst)
+}
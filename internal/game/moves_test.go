@@ -0,0 +1,181 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/staylor11x/spider-solitaire/internal/deck"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLegalMoves(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func() *GameState
+		want  []Move
+	}{
+		{
+			name:  "empty game has no moves",
+			setup: func() *GameState { return &GameState{} },
+			want:  nil,
+		},
+		{
+			name: "single card onto higher rank",
+			setup: func() *GameState {
+				g := &GameState{}
+				for i := range TableauPiles {
+					g.Tableau.Piles[i] = newPile(makeCardInPile(deck.Spades, deck.Five, true))
+				}
+				g.Tableau.Piles[0] = newPile(makeCardInPile(deck.Hearts, deck.Ten, true))
+				g.Tableau.Piles[1] = newPile(makeCardInPile(deck.Spades, deck.Jack, true))
+				return g
+			},
+			want: []Move{TableauMove(0, 0, 1)},
+		},
+		{
+			name: "partial and full sequences are listed, face-down cards are not",
+			setup: func() *GameState {
+				g := &GameState{}
+				for i := range TableauPiles {
+					g.Tableau.Piles[i] = newPile(makeCardInPile(deck.Spades, deck.Two, true))
+				}
+				g.Tableau.Piles[0] = newPile(
+					makeCardInPile(deck.Clubs, deck.Jack, false),
+					makeCardInPile(deck.Spades, deck.Ten, true),
+					makeCardInPile(deck.Spades, deck.Nine, true),
+				)
+				g.Tableau.Piles[1] = newPile(makeCardInPile(deck.Hearts, deck.Jack, true))
+				g.Tableau.Piles[2] = newPile(makeCardInPile(deck.Hearts, deck.Ten, true))
+				return g
+			},
+			want: []Move{
+				TableauMove(0, 1, 1), // 10-9 onto the jack
+				TableauMove(0, 2, 2), // 9 onto the ten
+				TableauMove(2, 0, 1), // ten onto the jack
+			},
+		},
+		{
+			name: "anything can move to an empty pile",
+			setup: func() *GameState {
+				g := &GameState{}
+				g.Tableau.Piles[0] = newPile(
+					makeCardInPile(deck.Spades, deck.Ten, true),
+					makeCardInPile(deck.Spades, deck.Nine, true),
+				)
+				// face-down aces can neither move nor accept anything
+				for i := 2; i < TableauPiles; i++ {
+					g.Tableau.Piles[i] = newPile(makeCardInPile(deck.Spades, deck.Ace, false))
+				}
+				return g
+			},
+			want: []Move{TableauMove(0, 0, 1), TableauMove(0, 1, 1)},
+		},
+		{
+			name: "deal is offered when the stock allows it",
+			setup: func() *GameState {
+				g := &GameState{Stock: make([]deck.Card, TableauPiles)}
+				for i := range TableauPiles {
+					g.Tableau.Piles[i] = newPile(makeCardInPile(deck.Spades, deck.Five, true))
+				}
+				return g
+			},
+			want: []Move{DealMove()},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := tt.setup()
+			assert.Equal(t, tt.want, g.LegalMoves())
+		})
+	}
+}
+
+func TestLegalMoves_AllApplySuccessfully(t *testing.T) {
+	for seed := range uint64(20) {
+		g, err := DealSeededGame(deck.FourSuits, seed)
+		require.NoError(t, err)
+
+		for _, m := range g.LegalMoves() {
+			fresh, err := DealSeededGame(deck.FourSuits, seed)
+			require.NoError(t, err)
+			assert.NoError(t, fresh.Apply(m), "seed %d move %s", seed, m)
+		}
+	}
+}
+
+func TestCanMove_MatchesMoveSequenceWithoutMutating(t *testing.T) {
+	var cfde CardFaceDownError
+
+	tests := []struct {
+		name            string
+		src, start, dst int
+		expectErr       error
+	}{
+		{"valid", 0, 1, 1, nil},
+		{"bad source", -1, 0, 1, ErrInvalidSourceIndex},
+		{"bad destination", 0, 0, TableauPiles, ErrInvalidDestinationIndex},
+		{"same pile", 0, 0, 0, ErrSamePileMove},
+		{"bad start", 0, 5, 1, ErrInvalidStartIndex},
+		{"face down", 0, 0, 1, cfde},
+		{"not accepting", 0, 1, 2, ErrDestinationNotAccepting},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := &GameState{}
+			g.Tableau.Piles[0] = newPile(
+				makeCardInPile(deck.Spades, deck.Two, false),
+				makeCardInPile(deck.Spades, deck.Ten, true),
+			)
+			g.Tableau.Piles[1] = newPile(makeCardInPile(deck.Hearts, deck.Jack, true))
+			g.Tableau.Piles[2] = newPile(makeCardInPile(deck.Hearts, deck.Four, true))
+			before := g.View()
+
+			err := g.CanMove(tt.src, tt.start, tt.dst)
+			if tt.expectErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tt.expectErr)
+			}
+			assert.Equal(t, before, g.View(), "CanMove must not mutate the game")
+			assert.Empty(t, g.history, "CanMove must not record history")
+		})
+	}
+}
+
+func TestMoveString(t *testing.T) {
+	assert.Equal(t, "3:5>7", TableauMove(3, 5, 7).String())
+	assert.Equal(t, "deal", DealMove().String())
+}
+
+func TestParseMove(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Move
+		wantErr bool
+	}{
+		{in: "3:5>7", want: TableauMove(3, 5, 7)},
+		{in: " 0:0>9 ", want: TableauMove(0, 0, 9)},
+		{in: "deal", want: DealMove()},
+		{in: "3:5", wantErr: true},
+		{in: "3>7", wantErr: true},
+		{in: "a:1>2", wantErr: true},
+		{in: "-1:0>2", wantErr: true},
+		{in: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseMove(tt.in)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidMoveNotation)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			again, err := ParseMove(got.String())
+			require.NoError(t, err)
+			assert.Equal(t, got, again, "String and ParseMove should round-trip")
+		})
+	}
+}
@@ -89,6 +89,32 @@ func (p *Pile) FlipTopCardIfFaceDown() error {
 	return nil
 }
 
+// showTop turns the top card face up, failing if it already is so a flip can't be replayed twice
+func (p *Pile) showTop() error {
+	if len(p.cards) == 0 {
+		return ErrNoCardsToMove
+	}
+	top := &p.cards[len(p.cards)-1]
+	if top.FaceUp {
+		return errors.New("top card is already face up")
+	}
+	top.FaceUp = true
+	return nil
+}
+
+// hideTop turns the top card face down again, reversing a flip
+func (p *Pile) hideTop() error {
+	if len(p.cards) == 0 {
+		return ErrNoCardsToMove
+	}
+	top := &p.cards[len(p.cards)-1]
+	if !top.FaceUp {
+		return errors.New("top card is already face down")
+	}
+	top.FaceUp = false
+	return nil
+}
+
 func (p *Pile) Clone() Pile {
 	clone := Pile{
 		cards: make([]CardInPile, len(p.cards)),
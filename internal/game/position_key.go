@@ -0,0 +1,42 @@
+package game
+
+import (
+	"slices"
+	"strings"
+)
+
+// PositionKey encodes everything that affects the future of a position, so a search can
+// skip positions it has already seen.
+//
+// Every position in one search descends from the same root, so the stock is
+// fully described by its length. Once the stock is empty no more rows are
+// dealt, piles become interchangeable and are sorted so that positions which
+// only differ by pile order share a key.
+func (g *GameState) PositionKey() string {
+	piles := make([]string, len(g.Tableau.Piles))
+	for i := range g.Tableau.Piles {
+		cards := g.Tableau.Piles[i].cards
+		b := make([]byte, len(cards))
+		for j, c := range cards {
+			// rank in the low nibble, suit in the next two bits, face-up flag above
+			v := byte(c.Card.Rank) | byte(c.Card.Suit)<<4
+			if c.FaceUp {
+				v |= 1 << 6
+			}
+			b[j] = v
+		}
+		piles[i] = string(b)
+	}
+
+	if len(g.Stock) == 0 {
+		slices.Sort(piles)
+	}
+
+	var sb strings.Builder
+	sb.WriteByte(byte(len(g.Stock)))
+	for _, p := range piles {
+		sb.WriteString(p)
+		sb.WriteByte(0xFF) // pile separator, never a valid card byte
+	}
+	return sb.String()
+}
@@ -0,0 +1,24 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/staylor11x/spider-solitaire/internal/deck"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPositionKey_IgnoresPileOrderOnlyWithoutStock(t *testing.T) {
+	a := &GameState{}
+	a.Tableau.Piles[0].AddCard(deck.Card{Suit: deck.Hearts, Rank: deck.Ten}, true)
+	b := &GameState{}
+	b.Tableau.Piles[5].AddCard(deck.Card{Suit: deck.Hearts, Rank: deck.Ten}, true)
+	assert.Equal(t, a.PositionKey(), b.PositionKey(), "piles are interchangeable once the stock is empty")
+
+	c := &GameState{}
+	c.Tableau.Piles[5].AddCard(deck.Card{Suit: deck.Hearts, Rank: deck.Ten}, false)
+	assert.NotEqual(t, b.PositionKey(), c.PositionKey(), "face-down cards are told apart")
+
+	a.Stock = make([]deck.Card, TableauPiles)
+	b.Stock = make([]deck.Card, TableauPiles)
+	assert.NotEqual(t, a.PositionKey(), b.PositionKey(), "deals make pile order matter")
+}
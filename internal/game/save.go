@@ -0,0 +1,346 @@
+package game
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"time"
+
+	"github.com/staylor11x/spider-solitaire/internal/deck"
+)
+
+// SaveVersion is the schema version written by Save.
+// Bump it whenever the saved shape changes and register a migration from the previous version.
+const SaveVersion = 1
+
+// saveMigrations upgrades a decoded save in place from version n (the key) to n+1.
+// Old saves are walked forward one step at a time until they reach SaveVersion.
+var saveMigrations = map[int]func(*saveFile) error{}
+
+// saveFile is the on-disk shape of a game. It is kept separate from GameState
+// so the engine can change without silently changing the file format.
+type saveFile struct {
+	Version   int    `json:"version"`
+	SuitCount int    `json:"suit_count"`
+	Seed      uint64 `json:"seed"`
+	Moves     int    `json:"moves"`
+	Deals     int    `json:"deals"`
+	Undos     int    `json:"undos"`
+	ElapsedMS int64  `json:"elapsed_ms"`
+	Scoring   string `json:"scoring,omitempty"` // registered policy name; empty means classic
+	savedState
+	Commands []savedCommand `json:"commands,omitempty"`
+	Redo     []savedCommand `json:"redo,omitempty"`
+}
+
+// savedState is one position of the game
+type savedState struct {
+	Tableau   [][]savedCard `json:"tableau"`
+	Stock     []savedCard   `json:"stock"`
+	Completed [][]savedCard `json:"completed,omitempty"`
+	Won       bool          `json:"won,omitempty"`
+	Lost      bool          `json:"lost,omitempty"`
+	Score     int           `json:"score"`
+}
+
+type savedCard struct {
+	Suit   int  `json:"s"`
+	Rank   int  `json:"r"`
+	FaceUp bool `json:"up,omitempty"`
+}
+
+// savedCommand is one undoable action: its steps and the score and result from before it
+type savedCommand struct {
+	Steps []savedStep `json:"steps"`
+	Score int         `json:"score"`
+	Won   bool        `json:"won,omitempty"`
+	Lost  bool        `json:"lost,omitempty"`
+	Auto  bool        `json:"auto,omitempty"`
+}
+
+type savedStep struct {
+	Kind  string `json:"k"`
+	Pile  int    `json:"p,omitempty"`
+	Dst   int    `json:"d,omitempty"`
+	Count int    `json:"n,omitempty"`
+}
+
+// Save writes the game, including its undo and redo history and scoring policy, in the
+// versioned save format. A policy other than ClassicScoring must have been registered
+// under its name by RegisterScoringPolicy.
+func (g *GameState) Save(w io.Writer) error {
+	var scoring string
+	if g.scoring != nil {
+		scoring = g.scoring.Name()
+		if _, ok := scoringPolicies[scoring]; !ok {
+			return fmt.Errorf("scoring policy %q is not registered", scoring)
+		}
+	}
+	file := saveFile{
+		Version:    SaveVersion,
+		SuitCount:  int(g.SuitCount),
+		Seed:       g.Seed,
+		Moves:      g.Moves,
+		Deals:      g.Deals,
+		Undos:      g.Undos,
+		ElapsedMS:  g.Elapsed().Milliseconds(),
+		Scoring:    scoring,
+		savedState: stateToSave(g),
+		Commands:   commandsToSave(g.history),
+		Redo:       commandsToSave(g.redo),
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(file)
+}
+
+// Load reads a game written by Save, migrating older save versions forward
+func Load(r io.Reader) (*GameState, error) {
+	var file saveFile
+	if err := json.NewDecoder(r).Decode(&file); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSave, err)
+	}
+
+	if file.Version < 1 || file.Version > SaveVersion {
+		return nil, SaveVersionError{Version: file.Version}
+	}
+	for v := file.Version; v < SaveVersion; v++ {
+		migrate, ok := saveMigrations[v]
+		if !ok {
+			return nil, SaveVersionError{Version: file.Version}
+		}
+		if err := migrate(&file); err != nil {
+			return nil, fmt.Errorf("%w: migrating from version %d: %v", ErrInvalidSave, v, err)
+		}
+		file.Version = v + 1
+	}
+
+	g, err := stateFromSave(file.savedState)
+	if err != nil {
+		return nil, err
+	}
+	g.SuitCount = deck.SuitCount(file.SuitCount)
+	g.Seed = file.Seed
+	g.Moves, g.Deals, g.Undos = file.Moves, file.Deals, file.Undos
+	g.elapsed = time.Duration(file.ElapsedMS) * time.Millisecond // resumes with the next action
+	if file.Scoring != "" {
+		newPolicy, ok := scoringPolicies[file.Scoring]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown scoring policy %q", ErrInvalidSave, file.Scoring)
+		}
+		g.scoring = newPolicy() // keeps the saved score, unlike SetScoringPolicy
+	}
+
+	if g.history, err = commandsFromSave(file.Commands); err != nil {
+		return nil, err
+	}
+	if g.redo, err = commandsFromSave(file.Redo); err != nil {
+		return nil, err
+	}
+	if err := checkHistory(g); err != nil {
+		return nil, err
+	}
+	if err := checkCards(g); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+func stateToSave(g *GameState) savedState {
+	s := savedState{
+		Tableau:   make([][]savedCard, len(g.Tableau.Piles)),
+		Stock:     make([]savedCard, len(g.Stock)),
+		Completed: make([][]savedCard, len(g.Completed)),
+		Won:       g.Won,
+		Lost:      g.Lost,
+		Score:     g.Score,
+	}
+	for i := range g.Tableau.Piles {
+		s.Tableau[i] = cardsToSave(g.Tableau.Piles[i].cards)
+	}
+	for i, c := range g.Stock {
+		s.Stock[i] = savedCard{Suit: int(c.Suit), Rank: int(c.Rank)}
+	}
+	for i, run := range g.Completed {
+		s.Completed[i] = cardsToSave(run)
+	}
+	return s
+}
+
+func cardsToSave(cards []CardInPile) []savedCard {
+	out := make([]savedCard, len(cards))
+	for i, c := range cards {
+		out[i] = savedCard{Suit: int(c.Card.Suit), Rank: int(c.Card.Rank), FaceUp: c.FaceUp}
+	}
+	return out
+}
+
+func stateFromSave(s savedState) (*GameState, error) {
+	if len(s.Tableau) != TableauPiles {
+		return nil, fmt.Errorf("%w: expected %d piles, got %d", ErrInvalidSave, TableauPiles, len(s.Tableau))
+	}
+
+	g := &GameState{Won: s.Won, Lost: s.Lost, Score: s.Score}
+	for i, pile := range s.Tableau {
+		cards, err := cardsFromSave(pile)
+		if err != nil {
+			return nil, err
+		}
+		g.Tableau.Piles[i].AddCards(cards)
+	}
+
+	g.Stock = make([]deck.Card, len(s.Stock))
+	for i, c := range s.Stock {
+		card, err := cardFromSave(c)
+		if err != nil {
+			return nil, err
+		}
+		g.Stock[i] = card
+	}
+
+	for _, run := range s.Completed {
+		cards, err := cardsFromSave(run)
+		if err != nil {
+			return nil, err
+		}
+		g.Completed = append(g.Completed, cards)
+	}
+	return g, nil
+}
+
+// checkCards makes sure the position holds exactly the cards of a deal at its suit count,
+// wherever they now are, so Restart and play from it behave like any other game
+func checkCards(g *GameState) error {
+	if !g.SuitCount.Valid() {
+		return fmt.Errorf("%w: bad suit count %d", ErrInvalidSave, g.SuitCount)
+	}
+	counts := make(map[deck.Card]int)
+	for _, c := range deck.NewSpiderDeck(g.SuitCount).Cards() {
+		counts[c]++
+	}
+	total := len(g.Stock)
+	for _, c := range g.Stock {
+		counts[c]--
+	}
+	for _, p := range g.Tableau.Piles {
+		total += p.Size()
+		for _, c := range p.cards {
+			counts[c.Card]--
+		}
+	}
+	for _, run := range g.Completed {
+		total += len(run)
+		for _, c := range run {
+			counts[c.Card]--
+		}
+	}
+	if total != TotalSpiderCards {
+		return fmt.Errorf("%w: %d cards, want %d", ErrInvalidSave, total, TotalSpiderCards)
+	}
+	for c, n := range counts {
+		if n != 0 {
+			return fmt.Errorf("%w: wrong number of %v for %d suits", ErrInvalidSave, c, g.SuitCount)
+		}
+	}
+	return nil
+}
+
+func cardsFromSave(saved []savedCard) ([]CardInPile, error) {
+	out := make([]CardInPile, len(saved))
+	for i, c := range saved {
+		card, err := cardFromSave(c)
+		if err != nil {
+			return nil, err
+		}
+		out[i] = CardInPile{Card: card, FaceUp: c.FaceUp}
+	}
+	return out, nil
+}
+
+func cardFromSave(c savedCard) (deck.Card, error) {
+	suit, rank := deck.Suit(c.Suit), deck.Rank(c.Rank)
+	if suit < deck.Spades || suit > deck.Clubs || rank < deck.Ace || rank > deck.King {
+		return deck.Card{}, fmt.Errorf("%w: bad card (suit=%d, rank=%d)", ErrInvalidSave, c.Suit, c.Rank)
+	}
+	return deck.Card{Suit: suit, Rank: rank}, nil
+}
+
+func commandsToSave(commands []command) []savedCommand {
+	if len(commands) == 0 {
+		return nil
+	}
+	out := make([]savedCommand, len(commands))
+	for i, c := range commands {
+		out[i] = savedCommand{Steps: make([]savedStep, len(c.steps)), Score: c.score, Won: c.won, Lost: c.lost, Auto: c.auto}
+		for j, s := range c.steps {
+			out[i].Steps[j] = savedStep{Kind: stepKindNames[s.kind], Pile: s.pile, Dst: s.dst, Count: s.count}
+		}
+	}
+	return out
+}
+
+// commandsFromSave checks each step is well formed. Whether the steps fit the position is
+// left to checkHistory.
+func commandsFromSave(saved []savedCommand) ([]command, error) {
+	var out []command
+	for _, sc := range saved {
+		if len(sc.Steps) == 0 {
+			return nil, fmt.Errorf("%w: history entry without steps", ErrInvalidSave)
+		}
+		c := command{steps: make([]step, len(sc.Steps)), score: sc.Score, won: sc.Won, lost: sc.Lost, auto: sc.Auto}
+		for i, ss := range sc.Steps {
+			s, err := stepFromSave(ss)
+			if err != nil {
+				return nil, err
+			}
+			c.steps[i] = s
+		}
+		out = append(out, c)
+	}
+	return out, nil
+}
+
+// checkHistory makes sure the loaded commands fit the position, by undoing the whole
+// history on a copy, doing it again, and then doing the redo list. Undo and Redo apply a
+// command one step at a time, so a step that only failed halfway through would leave the
+// game corrupted.
+func checkHistory(g *GameState) error {
+	c := g.Clone()
+	for i := len(g.history) - 1; i >= 0; i-- {
+		steps := g.history[i].steps
+		for j := len(steps) - 1; j >= 0; j-- {
+			if err := c.undo(steps[j]); err != nil {
+				return fmt.Errorf("%w: history doesn't fit the position: %v", ErrInvalidSave, err)
+			}
+		}
+	}
+	for _, cmd := range g.history {
+		for _, s := range cmd.steps {
+			if err := c.do(s); err != nil {
+				return fmt.Errorf("%w: history doesn't fit the position: %v", ErrInvalidSave, err)
+			}
+		}
+	}
+	for i := len(g.redo) - 1; i >= 0; i-- {
+		for _, s := range g.redo[i].steps {
+			if err := c.do(s); err != nil {
+				return fmt.Errorf("%w: redo list doesn't fit the position: %v", ErrInvalidSave, err)
+			}
+		}
+	}
+	return nil
+}
+
+func stepFromSave(ss savedStep) (step, error) {
+	kind := slices.Index(stepKindNames[:], ss.Kind)
+	bad := kind < 0 || ss.Pile < 0 || ss.Pile >= TableauPiles || ss.Dst < 0 || ss.Dst >= TableauPiles
+	if kind == int(stepMove) {
+		bad = bad || ss.Count < 1 || ss.Pile == ss.Dst
+	}
+	if bad {
+		return step{}, fmt.Errorf("%w: bad history step %+v", ErrInvalidSave, ss)
+	}
+	return step{kind: stepKind(kind), pile: ss.Pile, dst: ss.Dst, count: ss.Count}, nil
+}
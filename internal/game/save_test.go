@@ -0,0 +1,223 @@
+package game
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/staylor11x/spider-solitaire/internal/deck"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSaveLoad_RoundTrip(t *testing.T) {
+	g, err := DealSeededGame(deck.TwoSuits, 99)
+	require.NoError(t, err)
+	clock := newFakeClock()
+	g.SetClock(clock)
+	require.NoError(t, g.DealRow())
+	require.NoError(t, g.DealRow())
+	require.NoError(t, g.Undo())
+	require.NoError(t, g.DealRow())
+	clock.Advance(90 * time.Second)
+
+	var buf bytes.Buffer
+	require.NoError(t, g.Save(&buf))
+
+	loaded, err := Load(&buf)
+	require.NoError(t, err)
+
+	assert.Equal(t, g.Tableau, loaded.Tableau)
+	assert.Equal(t, g.Stock, loaded.Stock)
+	assert.Equal(t, g.Completed, loaded.Completed)
+	assert.Equal(t, g.Won, loaded.Won)
+	assert.Equal(t, g.Lost, loaded.Lost)
+	assert.Equal(t, g.SuitCount, loaded.SuitCount)
+	assert.Equal(t, g.Seed, loaded.Seed)
+	assert.Equal(t, g.Score, loaded.Score)
+	assert.Equal(t, 3, loaded.Deals)
+	assert.Equal(t, 1, loaded.Undos)
+	assert.Equal(t, 90*time.Second, loaded.Elapsed())
+	assert.Equal(t, g.history, loaded.history)
+	assert.Empty(t, loaded.redo)
+}
+
+func TestSaveLoad_UndoWorksAfterLoad(t *testing.T) {
+	g, err := DealSeededGame(deck.OneSuit, 7)
+	require.NoError(t, err)
+	initial := g.View()
+	require.NoError(t, g.DealRow())
+
+	var buf bytes.Buffer
+	require.NoError(t, g.Save(&buf))
+	loaded, err := Load(&buf)
+	require.NoError(t, err)
+
+	require.NoError(t, loaded.Undo())
+	assert.Equal(t, initial.Tableau, loaded.View().Tableau)
+	assert.Equal(t, initial.StockCount, loaded.View().StockCount)
+	assert.ErrorIs(t, loaded.Undo(), ErrNoHistory)
+}
+
+func TestSaveLoad_RedoWorksAfterLoad(t *testing.T) {
+	g, err := DealSeededGame(deck.OneSuit, 7)
+	require.NoError(t, err)
+	require.NoError(t, g.DealRow())
+	dealt := g.View()
+	require.NoError(t, g.Undo())
+
+	var buf bytes.Buffer
+	require.NoError(t, g.Save(&buf))
+	loaded, err := Load(&buf)
+	require.NoError(t, err)
+
+	require.NoError(t, loaded.Redo())
+	assert.Equal(t, dealt.Tableau, loaded.View().Tableau)
+	assert.Equal(t, dealt.StockCount, loaded.View().StockCount)
+	assert.ErrorIs(t, loaded.Redo(), ErrNoRedo)
+}
+
+func TestSaveLoad_KeepsAutoCompleteForRedo(t *testing.T) {
+	g := AlmostWon()
+	require.NoError(t, g.AutoComplete())
+	require.NoError(t, g.Undo())
+
+	var buf bytes.Buffer
+	require.NoError(t, g.Save(&buf))
+	loaded, err := Load(&buf)
+	require.NoError(t, err)
+
+	require.NoError(t, loaded.Redo())
+	assert.Equal(t, Event{Kind: EventAutoCompleted, Count: 1}, loaded.LastEvents()[0])
+}
+
+func TestSaveLoad_PreservesWonLostFlags(t *testing.T) {
+	g, err := stateFromSave(spadeRuns(TotalRunsToWin))
+	require.NoError(t, err)
+	g.SuitCount, g.Won = deck.OneSuit, true
+
+	var buf bytes.Buffer
+	require.NoError(t, g.Save(&buf))
+	loaded, err := Load(&buf)
+	require.NoError(t, err)
+
+	assert.True(t, loaded.Won)
+	assert.False(t, loaded.Lost)
+	assert.Equal(t, g.Completed, loaded.Completed)
+}
+
+// spadeRuns is a one-suit position with n King-to-Ace runs completed, the rest of the
+// deck in the stock and the tableau empty
+func spadeRuns(n int) savedState {
+	s := savedState{Tableau: make([][]savedCard, TableauPiles)}
+	for range n {
+		s.Completed = append(s.Completed, cardsToSave(CompletedRun(deck.Spades)))
+	}
+	for range TotalRunsToWin - n {
+		for r := deck.Ace; r <= deck.King; r++ {
+			s.Stock = append(s.Stock, savedCard{Suit: int(deck.Spades), Rank: int(r)})
+		}
+	}
+	return s
+}
+
+func TestSaveLoad_KeepsScoringPolicy(t *testing.T) {
+	RegisterScoringPolicy("flat", func() ScoringPolicy { return flatScoring{} })
+	g, err := DealSeededGame(deck.OneSuit, 5)
+	require.NoError(t, err)
+	g.SetScoringPolicy(flatScoring{})
+	require.NoError(t, g.DealRow())
+
+	var buf bytes.Buffer
+	require.NoError(t, g.Save(&buf))
+	loaded, err := Load(&buf)
+	require.NoError(t, err)
+	assert.Equal(t, flatScoring{}, loaded.scoring)
+	assert.Equal(t, 0, loaded.Score)
+
+	require.NoError(t, loaded.DealRow())
+	assert.Equal(t, 0, loaded.Score, "later actions score by the saved policy")
+	restarted, err := loaded.Restart()
+	require.NoError(t, err)
+	assert.Equal(t, 0, restarted.Score, "a restart after loading keeps the policy too")
+}
+
+func TestSaveLoad_KeepsScoringPolicyConfiguration(t *testing.T) {
+	RegisterScoringPolicy("cost-1", func() ScoringPolicy { return costScoring{cost: 1} })
+	RegisterScoringPolicy("cost-2", func() ScoringPolicy { return costScoring{cost: 2} })
+	g, err := DealSeededGame(deck.OneSuit, 5)
+	require.NoError(t, err)
+	g.SetScoringPolicy(costScoring{cost: 2})
+
+	var buf bytes.Buffer
+	require.NoError(t, g.Save(&buf))
+	loaded, err := Load(&buf)
+	require.NoError(t, err)
+	assert.Equal(t, costScoring{cost: 2}, loaded.scoring)
+}
+
+func TestSave_RejectsUnregisteredScoringPolicy(t *testing.T) {
+	g := &GameState{}
+	g.SetScoringPolicy(floorScoring{})
+	assert.Error(t, g.Save(&bytes.Buffer{}))
+
+	g.SetScoringPolicy(costScoring{cost: 7})
+	assert.Error(t, g.Save(&bytes.Buffer{}), "a configuration that was never registered")
+}
+
+func TestLoad_RejectsBadInput(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		expectErr error
+	}{
+		{"not json", "not a save", ErrInvalidSave},
+		{"newer version", `{"version": 999}`, SaveVersionError{Version: 999}},
+		{"missing version", `{}`, SaveVersionError{Version: 0}},
+		{"wrong pile count", `{"version": 1, "tableau": [[]]}`, ErrInvalidSave},
+		{"bad card", `{"version": 1, "tableau": [[{"s": 9, "r": 1}],[],[],[],[],[],[],[],[],[]]}`, ErrInvalidSave},
+		{"unknown history step", `{"version": 1, "tableau": [[],[],[],[],[],[],[],[],[],[]], "commands": [{"steps": [{"k": "shuffle"}]}]}`, ErrInvalidSave},
+		{"history step off the table", `{"version": 1, "tableau": [[],[],[],[],[],[],[],[],[],[]], "redo": [{"steps": [{"k": "flip", "p": 10}]}]}`, ErrInvalidSave},
+		{"unknown scoring policy", `{"version": 1, "scoring": "golf", "tableau": [[],[],[],[],[],[],[],[],[],[]]}`, ErrInvalidSave},
+		{"history that fails halfway through", `{"version": 1, "tableau": [[{"s": 0, "r": 1, "up": true}],[],[],[],[],[],[],[],[],[]], "commands": [{"steps": [{"k": "move", "p": 1, "n": 5}, {"k": "move", "p": 1, "n": 1}]}]}`, ErrInvalidSave},
+		{"history flip of a hidden card", `{"version": 1, "tableau": [[{"s": 0, "r": 1}],[],[],[],[],[],[],[],[],[]], "commands": [{"steps": [{"k": "flip"}]}]}`, ErrInvalidSave},
+		{"redo flip of a card already showing", `{"version": 1, "tableau": [[{"s": 0, "r": 1, "up": true}],[],[],[],[],[],[],[],[],[]], "redo": [{"steps": [{"k": "flip"}]}]}`, ErrInvalidSave},
+		{"redo that doesn't fit", `{"version": 1, "tableau": [[],[],[],[],[],[],[],[],[],[]], "redo": [{"steps": [{"k": "deal"}]}]}`, ErrInvalidSave},
+		{"empty history entry", `{"version": 1, "tableau": [[],[],[],[],[],[],[],[],[],[]], "commands": [{"steps": []}]}`, ErrInvalidSave},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Load(strings.NewReader(tt.input))
+			assert.ErrorIs(t, err, tt.expectErr)
+		})
+	}
+}
+
+func TestLoad_RejectsWrongCards(t *testing.T) {
+	tests := []struct {
+		name   string
+		suits  deck.SuitCount
+		change func(s *savedState)
+	}{
+		{"unsupported suit count", 3, func(*savedState) {}},
+		{"card missing", deck.OneSuit, func(s *savedState) { s.Stock = s.Stock[1:] }},
+		{"extra card", deck.OneSuit, func(s *savedState) { s.Tableau[0] = append(s.Tableau[0], s.Stock[0]) }},
+		{"suit the deal doesn't use", deck.OneSuit, func(s *savedState) { s.Stock[0].Suit = int(deck.Hearts) }},
+		{"runs of one suit in a two-suit deal", deck.TwoSuits, func(*savedState) {}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := spadeRuns(2)
+			tt.change(&s)
+			data, err := json.Marshal(saveFile{Version: SaveVersion, SuitCount: int(tt.suits), savedState: s})
+			require.NoError(t, err)
+
+			_, err = Load(bytes.NewReader(data))
+			assert.ErrorIs(t, err, ErrInvalidSave)
+		})
+	}
+}
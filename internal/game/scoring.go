@@ -0,0 +1,78 @@
+package game
+
+// ScoreAction is a scoring-relevant event reported to the ScoringPolicy
+type ScoreAction int
+
+const (
+	ScoreMove         ScoreAction = iota // a sequence was moved between piles
+	ScoreDeal                            // a row was dealt from the stock
+	ScoreRunCompleted                    // a King-to-Ace run was removed to Completed
+	ScoreUndo                            // an action was undone (after the score was restored)
+)
+
+// ScoringPolicy decides how the score starts and changes.
+// Implementations must be deterministic so replays and saves agree.
+type ScoringPolicy interface {
+	Name() string // recorded in saves; a configurable policy includes its configuration
+	InitialScore() int
+	Score(current int, action ScoreAction) int
+}
+
+// Classic spider scoring constants
+const (
+	classicInitialScore = 500
+	classicActionCost   = 1
+	classicRunBonus     = 100
+)
+
+// ClassicScoring is the traditional spider scheme: start at 500, pay one point
+// per move, deal or undo, and earn 100 for every completed run.
+type ClassicScoring struct{}
+
+func (ClassicScoring) Name() string { return "classic" }
+
+func (ClassicScoring) InitialScore() int { return classicInitialScore }
+
+func (ClassicScoring) Score(current int, action ScoreAction) int {
+	switch action {
+	case ScoreRunCompleted:
+		return current + classicRunBonus
+	case ScoreMove, ScoreDeal, ScoreUndo:
+		return current - classicActionCost
+	default:
+		return current
+	}
+}
+
+// scoringPolicies builds the policies a save can name, so a resumed game keeps scoring
+// the way it started
+var scoringPolicies = map[string]func() ScoringPolicy{
+	"classic": func() ScoringPolicy { return ClassicScoring{} },
+}
+
+// RegisterScoringPolicy lets games scored by a policy named name be saved and loaded.
+// Load calls newPolicy to rebuild the policy, which must report name from its Name method.
+// ClassicScoring is registered already. Call it from an init function: the registry
+// isn't locked, and a save is only readable once its policy is registered.
+func RegisterScoringPolicy(name string, newPolicy func() ScoringPolicy) {
+	scoringPolicies[name] = newPolicy
+}
+
+// SetScoringPolicy swaps the scoring scheme and resets Score to its initial value.
+// Call it on a fresh deal, before any moves.
+func (g *GameState) SetScoringPolicy(p ScoringPolicy) {
+	g.scoring = p
+	g.Score = p.InitialScore()
+}
+
+// scoringPolicy returns the active policy, defaulting to classic scoring
+func (g *GameState) scoringPolicy() ScoringPolicy {
+	if g.scoring == nil {
+		return ClassicScoring{}
+	}
+	return g.scoring
+}
+
+func (g *GameState) applyScore(action ScoreAction) {
+	g.Score = g.scoringPolicy().Score(g.Score, action)
+}
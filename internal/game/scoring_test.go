@@ -0,0 +1,165 @@
+package game
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/staylor11x/spider-solitaire/internal/deck"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClassicScoring(t *testing.T) {
+	tests := []struct {
+		name   string
+		action ScoreAction
+		want   int
+	}{
+		{"move costs a point", ScoreMove, 499},
+		{"deal costs a point", ScoreDeal, 499},
+		{"undo costs a point", ScoreUndo, 499},
+		{"run earns 100", ScoreRunCompleted, 600},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ClassicScoring{}.Score(500, tt.action))
+		})
+	}
+}
+
+func TestScoring_NewDealStartsAt500(t *testing.T) {
+	g, err := DealSeededGame(deck.OneSuit, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 500, g.Score)
+	assert.Equal(t, 500, g.View().Score)
+}
+
+func TestScoring_MoveAndDealCostAPoint(t *testing.T) {
+	g := &GameState{Score: 500, Stock: make([]deck.Card, TableauPiles)}
+	g.Tableau.Piles[0] = newPile(makeCardInPile(deck.Spades, deck.Ten, true))
+	g.Tableau.Piles[1] = newPile(makeCardInPile(deck.Hearts, deck.Jack, true))
+
+	require.NoError(t, g.MoveSequence(0, 0, 1))
+	assert.Equal(t, 499, g.Score)
+
+	require.NoError(t, g.DealRow())
+	assert.Equal(t, 498, g.Score)
+}
+
+func TestScoring_FailedMoveIsFree(t *testing.T) {
+	g := &GameState{Score: 500}
+	g.Tableau.Piles[0] = newPile(makeCardInPile(deck.Spades, deck.Ten, true))
+	g.Tableau.Piles[1] = newPile(makeCardInPile(deck.Hearts, deck.Four, true))
+
+	assert.Error(t, g.MoveSequence(0, 0, 1))
+	assert.Equal(t, 500, g.Score)
+}
+
+func TestScoring_CompletedRunEarnsBonus(t *testing.T) {
+	g := &GameState{Score: 500}
+	g.Tableau.Piles[0].AddCards(newSequenceWithIgnoreRank(deck.Spades, deck.Ace))
+	g.Tableau.Piles[1].AddCard(deck.Card{Suit: deck.Spades, Rank: deck.Ace}, true)
+
+	require.NoError(t, g.MoveSequence(1, 0, 0))
+	assert.Equal(t, 500-1+100, g.Score)
+}
+
+func TestScoring_UndoRestoresScoreAndCountsAsMove(t *testing.T) {
+	g := &GameState{Score: 500}
+	g.Tableau.Piles[0].AddCards(newSequenceWithIgnoreRank(deck.Spades, deck.Ace))
+	g.Tableau.Piles[1].AddCard(deck.Card{Suit: deck.Spades, Rank: deck.Ace}, true)
+
+	require.NoError(t, g.MoveSequence(1, 0, 0))
+	require.NoError(t, g.Undo())
+
+	// back to 500 for the restored position, then one point for the undo
+	assert.Equal(t, 499, g.Score)
+}
+
+// flatScoring is a minimal alternative scheme used to prove the policy is pluggable
+type flatScoring struct{}
+
+func (flatScoring) Name() string      { return "flat" }
+func (flatScoring) InitialScore() int { return 0 }
+func (flatScoring) Score(current int, action ScoreAction) int {
+	if action == ScoreRunCompleted {
+		return current + 1
+	}
+	return current
+}
+
+func TestScoring_PluggablePolicy(t *testing.T) {
+	g := &GameState{}
+	g.SetScoringPolicy(flatScoring{})
+	g.Tableau.Piles[0].AddCards(newSequenceWithIgnoreRank(deck.Spades, deck.Ace))
+	g.Tableau.Piles[1].AddCard(deck.Card{Suit: deck.Spades, Rank: deck.Ace}, true)
+
+	require.NoError(t, g.MoveSequence(1, 0, 0))
+	assert.Equal(t, 1, g.Score)
+
+	require.NoError(t, g.Undo())
+	assert.Equal(t, 0, g.Score, "undo uses the same policy")
+
+	assert.Equal(t, 0, g.Clone().Score)
+}
+
+// floorScoring charges for every action but never goes below zero, so the order actions
+// are scored in changes the result
+type floorScoring struct{}
+
+func (floorScoring) Name() string      { return "floor" }
+func (floorScoring) InitialScore() int { return 0 }
+func (floorScoring) Score(current int, action ScoreAction) int {
+	if action == ScoreRunCompleted {
+		return current + 10
+	}
+	return max(current-1, 0)
+}
+
+// costScoring charges a configurable amount per action
+type costScoring struct{ cost int }
+
+func (c costScoring) Name() string    { return fmt.Sprintf("cost-%d", c.cost) }
+func (costScoring) InitialScore() int { return 0 }
+func (c costScoring) Score(current int, action ScoreAction) int {
+	return current - c.cost
+}
+
+func TestRedo_ScoresInTheOriginalOrder(t *testing.T) {
+	dealCompletesRun := func() *GameState {
+		g := &GameState{}
+		g.SetScoringPolicy(floorScoring{})
+		g.Tableau.Piles[0].AddCards(CompletedRun(deck.Spades)[:RunLength-1])
+		for range TableauPiles - 1 {
+			g.Stock = append(g.Stock, deck.Card{Suit: deck.Hearts, Rank: deck.King})
+		}
+		g.Stock = append(g.Stock, deck.Card{Suit: deck.Spades, Rank: deck.Ace}) // dealt onto pile 0
+		return g
+	}
+	autoComplete := func() *GameState {
+		g := almostWonGame()
+		g.SetScoringPolicy(floorScoring{})
+		return g
+	}
+
+	for name, tt := range map[string]struct {
+		setup  func() *GameState
+		action func(g *GameState) error
+	}{
+		"deal":          {dealCompletesRun, (*GameState).DealRow},
+		"auto-complete": {autoComplete, (*GameState).AutoComplete},
+	} {
+		t.Run(name, func(t *testing.T) {
+			g := tt.setup()
+			require.NoError(t, tt.action(g))
+			require.NotEmpty(t, g.Completed)
+			require.NoError(t, g.Undo())
+
+			fresh := g.Clone()
+			require.NoError(t, tt.action(fresh))
+			require.NoError(t, g.Redo())
+			assert.Equal(t, fresh.Score, g.Score, "redo scores like playing the action again")
+		})
+	}
+}
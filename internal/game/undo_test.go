@@ -135,36 +135,27 @@ func TestUndo_MultipleUndos(t *testing.T) {
 	assert.ErrorIs(t, err, ErrNoHistory)
 }
 
-func TestUndo_HistoryLimit(t *testing.T) {
-	g, err := DealInitialGame(deck.OneSuit)
-	require.NoError(t, err)
-
-	dealsPerformed := 0
-	for range maxHistorySize + 5 {
-		if len(g.Stock) >= TableauPiles {
-			err := g.DealRow()
-			if err == nil {
-				dealsPerformed++
-			}
-		}
-	}
-
-	// history should be capped at maxHistorySize
-	assert.LessOrEqual(t, len(g.history), maxHistorySize, "history size should not exceed max size")
+func TestUndo_Unbounded(t *testing.T) {
+	// a Seven passed back and forth between a pile and an empty pile, far more times
+	// than any fixed history would hold
+	g := &GameState{}
+	g.Tableau.Piles[0].AddCard(deck.Card{Suit: deck.Spades, Rank: deck.Seven}, true)
+	g.Tableau.Piles[1].AddCard(deck.Card{Suit: deck.Spades, Rank: deck.Eight}, true)
+	initial := g.View()
 
-	// should be able to undo up to maxHistorySize times
-	successfulUndos := 0
-	for range maxHistorySize + 5 {
-		err := g.Undo()
-		if err == nil {
-			successfulUndos++
+	const moves = 500
+	for i := range moves {
+		if i%2 == 0 {
+			require.NoError(t, g.MoveSequence(0, 0, 1))
 		} else {
-			assert.ErrorIs(t, err, ErrNoHistory)
-			break
+			require.NoError(t, g.MoveSequence(1, 1, 0))
 		}
 	}
-
-	assert.LessOrEqual(t, successfulUndos, maxHistorySize, "should not undo more than the max history size")
+	for range moves {
+		require.NoError(t, g.Undo())
+	}
+	assert.ErrorIs(t, g.Undo(), ErrNoHistory)
+	assert.Equal(t, initial.Tableau, g.View().Tableau)
 }
 
 func TestUndo_PreserveWonLostFlags(t *testing.T) {
@@ -249,3 +240,114 @@ func TestUndo_DeepCopyVerification(t *testing.T) {
 	restoredTop, _ := g.Tableau.Piles[0].TopCard()
 	assert.Equal(t, initialTop.Card, restoredTop.Card, "deep copy should preserve card identity")
 }
+
+func TestClone_IsIndependentAndDropsHistory(t *testing.T) {
+	g, err := DealSeededGame(deck.TwoSuits, 11)
+	require.NoError(t, err)
+	g.SetClock(newFakeClock())
+	require.NoError(t, g.DealRow())
+
+	c := g.Clone()
+	assert.Equal(t, g.View(), c.View())
+	assert.ErrorIs(t, c.Undo(), ErrNoHistory, "clone should not carry undo history")
+
+	require.NoError(t, c.DealRow())
+	assert.NotEqual(t, g.View(), c.View(), "changing the clone must not affect the original")
+}
+
+func TestUndo_RevealAndRunRestoreFaceDownCards(t *testing.T) {
+	// pile 0 has a hidden card under an almost complete run; pile 1 holds the Ace
+	g := &GameState{}
+	g.Tableau.Piles[0].AddCard(deck.Card{Suit: deck.Hearts, Rank: deck.Four}, false)
+	g.Tableau.Piles[0].AddCards(newSequenceWithIgnoreRank(deck.Spades, deck.Ace))
+	g.Tableau.Piles[1].AddCard(deck.Card{Suit: deck.Spades, Rank: deck.Ten}, false)
+	g.Tableau.Piles[1].AddCard(deck.Card{Suit: deck.Spades, Rank: deck.Ace}, true)
+	before := g.Tableau.Piles
+
+	require.NoError(t, g.MoveSequence(1, 1, 0))
+	require.Len(t, g.Completed, 1)
+	top, _ := g.Tableau.Piles[0].TopCard()
+	assert.True(t, top.FaceUp, "collecting the run reveals the card under it")
+
+	require.NoError(t, g.Undo())
+	assert.Equal(t, before, g.Tableau.Piles, "flips and the collected run are reversed")
+	assert.Empty(t, g.Completed)
+}
+
+func TestRedo_ReappliesUndoneActions(t *testing.T) {
+	g, err := DealSeededGame(deck.OneSuit, 1)
+	require.NoError(t, err)
+	require.NoError(t, g.MoveSequence(0, 5, 4))
+	require.NoError(t, g.DealRow())
+	after := g.View()
+
+	require.NoError(t, g.Undo())
+	require.NoError(t, g.Undo())
+	require.NoError(t, g.Redo())
+	assert.Equal(t, []EventKind{EventMoveApplied, EventCardRevealed}, kinds(g.LastEvents()), "redo emits the action's events")
+	require.NoError(t, g.Redo())
+	assert.ErrorIs(t, g.Redo(), ErrNoRedo)
+
+	redone := g.View()
+	assert.Equal(t, after.Tableau, redone.Tableau)
+	assert.Equal(t, after.StockCount, redone.StockCount)
+	assert.Equal(t, 2, redone.Moves, "a redone move counts again")
+	assert.Equal(t, 2, redone.Deals)
+	// the undos restored 500 and cost a point; each redo then cost a point like the original action
+	assert.Equal(t, 500-1-2, redone.Score)
+
+	// the redone actions can be undone again
+	require.NoError(t, g.Undo())
+	require.NoError(t, g.Undo())
+	assert.ErrorIs(t, g.Undo(), ErrNoHistory)
+}
+
+func TestRedo_ClearedByNewAction(t *testing.T) {
+	g, err := DealSeededGame(deck.OneSuit, 1)
+	require.NoError(t, err)
+	assert.ErrorIs(t, g.Redo(), ErrNoRedo)
+
+	require.NoError(t, g.DealRow())
+	require.NoError(t, g.Undo())
+	require.NoError(t, g.MoveSequence(0, 5, 4))
+	assert.ErrorIs(t, g.Redo(), ErrNoRedo, "a new move replaces the undone deal")
+}
+
+func TestRedo_WinningRunWinsAgain(t *testing.T) {
+	g := AlmostWon()
+
+	require.NoError(t, g.MoveSequence(1, 0, 0))
+	require.True(t, g.Won)
+	require.NoError(t, g.Undo())
+	assert.False(t, g.Won)
+
+	require.NoError(t, g.Redo())
+	assert.True(t, g.Won)
+	assert.Contains(t, kinds(g.LastEvents()), EventGameWon)
+}
+
+func TestRewind_KeepsActionsForRedo(t *testing.T) {
+	g, err := DealSeededGame(deck.OneSuit, 1)
+	require.NoError(t, err)
+	initial := g.View()
+	require.NoError(t, g.MoveSequence(0, 5, 4))
+	require.NoError(t, g.DealRow())
+	after := g.View()
+
+	var undos int
+	g.Subscribe(func(e Event) {
+		if e.Kind == EventUndoApplied {
+			undos++
+		}
+	})
+	require.NoError(t, g.Rewind())
+	assert.Equal(t, initial.Tableau, g.View().Tableau)
+	assert.Equal(t, initial.StockCount, g.View().StockCount)
+	assert.Equal(t, 2, undos, "observers see one undo per action")
+	assert.Equal(t, initial.Score-1, g.Score, "the starting score less one undo")
+	assert.ErrorIs(t, g.Rewind(), ErrNoHistory)
+
+	require.NoError(t, g.Redo())
+	require.NoError(t, g.Redo())
+	assert.Equal(t, after.Tableau, g.View().Tableau)
+}
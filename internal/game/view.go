@@ -1,5 +1,7 @@
 package game
 
+import "time"
+
 // UI-safe value types (primitives to avoid UI depending on internal types).
 // These map 1:1 to internal deck enums but keep the UI decoupled.
 type SuitDTO int
@@ -7,28 +9,41 @@ type RankDTO int
 
 // CardDTO is a single card snapshot for rendering.
 // Rank/Suit values mirror internal deck enums; FaceUp indicates visibility.
+// In JSON a face-down card carries only face_up, see view_json.go.
 type CardDTO struct {
-	Rank   RankDTO
-	Suit   SuitDTO
-	FaceUp bool
+	Rank   RankDTO `json:"rank"`
+	Suit   SuitDTO `json:"suit"`
+	FaceUp bool    `json:"face_up"`
 }
 
 // PileDTO is a rendering-friendly pile snapshot.
 // Cards are ordered from bottom (index 0) to top (last index).
 type PileDTO struct {
-	Cards []CardDTO
+	Cards []CardDTO `json:"cards"`
 }
 
 // GameViewDTO is the full UI snapshot.
 // - Tableau: leftmost pile is index 0, rightmost is index 9.
 // - StockCount: cards remaining in stock.
 // - CompletedCount: completed runs removed from tableau.
+// - CompletedSuits: the suit of each completed run, in the order they were completed.
+// - SuitCount/Seed: identify the deal so it can be replayed or shared.
+// - Score: current score under the game's scoring policy.
+// - Moves/Deals/Undos/Elapsed: play statistics; Elapsed is frozen at snapshot time.
 type GameViewDTO struct {
-	Tableau        []PileDTO
-	StockCount     int
-	CompletedCount int
-	Won            bool
-	Lost           bool
+	Tableau        []PileDTO     `json:"tableau"`
+	StockCount     int           `json:"stock_count"`
+	CompletedCount int           `json:"completed_count"`
+	CompletedSuits []SuitDTO     `json:"completed_suits,omitempty"`
+	Won            bool          `json:"won"`
+	Lost           bool          `json:"lost"`
+	SuitCount      int           `json:"suit_count"`
+	Seed           uint64        `json:"seed,string"` // as a string: JavaScript numbers lose precision past 2^53
+	Score          int           `json:"score"`
+	Moves          int           `json:"moves"`
+	Deals          int           `json:"deals"`
+	Undos          int           `json:"undos"`
+	Elapsed        time.Duration `json:"-"` // encoded as elapsed_ms
 }
 
 func (g *GameState) View() GameViewDTO {
@@ -36,14 +51,49 @@ func (g *GameState) View() GameViewDTO {
 	for i := range g.Tableau.Piles {
 		tableau[i] = pileToDTO(g.Tableau.Piles[i])
 	}
+	var suits []SuitDTO
+	for _, run := range g.Completed {
+		var suit SuitDTO
+		if len(run) > 0 {
+			suit = SuitDTO(run[0].Card.Suit)
+		}
+		suits = append(suits, suit)
+	}
 
 	return GameViewDTO{
 		Tableau:        tableau,
 		StockCount:     len(g.Stock),
 		CompletedCount: len(g.Completed),
+		CompletedSuits: suits,
 		Won:            g.Won,
 		Lost:           g.Lost,
+		SuitCount:      int(g.SuitCount),
+		Seed:           g.Seed,
+		Score:          g.Score,
+		Moves:          g.Moves,
+		Deals:          g.Deals,
+		Undos:          g.Undos,
+		Elapsed:        g.Elapsed(),
+	}
+}
+
+// Masked returns a copy of the view with the rank and suit of face-down cards cleared,
+// i.e. only what a player at the table could see. Bots and remote clients should get this.
+func (v GameViewDTO) Masked() GameViewDTO {
+	masked := v
+	masked.Tableau = make([]PileDTO, len(v.Tableau))
+	for i, pile := range v.Tableau {
+		cards := make([]CardDTO, len(pile.Cards))
+		for j, c := range pile.Cards {
+			if c.FaceUp {
+				cards[j] = c
+			} else {
+				cards[j] = CardDTO{}
+			}
+		}
+		masked.Tableau[i] = PileDTO{Cards: cards}
 	}
+	return masked
 }
 
 func pileToDTO(p Pile) PileDTO {
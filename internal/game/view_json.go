@@ -0,0 +1,134 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/staylor11x/spider-solitaire/internal/deck"
+)
+
+// ViewSchemaVersion identifies the JSON encoding of GameViewDTO. It is bumped whenever
+// a field is removed or changes meaning; new fields may be added without a bump.
+//
+// A view encodes as:
+//
+//	{
+//	  "schema": 1,
+//	  "tableau": [{"cards": [{"face_up": false}, {"rank": "Q", "suit": "hearts", "face_up": true}]}, ...],
+//	  "stock_count": 50, "completed_count": 1, "completed_suits": ["hearts"], "won": false, "lost": false,
+//	  "suit_count": 2, "seed": "12345", "score": 500,
+//	  "moves": 0, "deals": 0, "undos": 0, "elapsed_ms": 0
+//	}
+//
+// Ranks are "A", "2"-"10", "J", "Q", "K"; suits are "spades", "hearts", "diamonds", "clubs".
+// Face-down cards carry only face_up, so the encoding never leaks hidden cards.
+// completed_suits is left out until a run has been completed.
+const ViewSchemaVersion = 1
+
+var rankNames = [...]string{"", "A", "2", "3", "4", "5", "6", "7", "8", "9", "10", "J", "Q", "K"}
+
+// MarshalText encodes the rank as "A", "2"-"10", "J", "Q" or "K"
+func (r RankDTO) MarshalText() ([]byte, error) {
+	if r < RankDTO(deck.Ace) || r > RankDTO(deck.King) {
+		return nil, fmt.Errorf("invalid rank %d", int(r))
+	}
+	return []byte(rankNames[r]), nil
+}
+
+// UnmarshalText decodes the form written by MarshalText
+func (r *RankDTO) UnmarshalText(text []byte) error {
+	for i := int(deck.Ace); i <= int(deck.King); i++ {
+		if rankNames[i] == string(text) {
+			*r = RankDTO(i)
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid rank %q", text)
+}
+
+// MarshalText encodes the suit as its lowercase name, e.g. "spades"
+func (s SuitDTO) MarshalText() ([]byte, error) {
+	if s < SuitDTO(deck.Spades) || s > SuitDTO(deck.Clubs) {
+		return nil, fmt.Errorf("invalid suit %d", int(s))
+	}
+	return []byte(strings.ToLower(deck.Suit(s).String())), nil
+}
+
+// UnmarshalText decodes the form written by MarshalText
+func (s *SuitDTO) UnmarshalText(text []byte) error {
+	for i := deck.Spades; i <= deck.Clubs; i++ {
+		if strings.ToLower(i.String()) == string(text) {
+			*s = SuitDTO(i)
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid suit %q", text)
+}
+
+// cardJSON is CardDTO on the wire, with the same field names. It uses pointers so a
+// face-down card omits rank and suit while a face-up spade (suit 0) still encodes its suit.
+type cardJSON struct {
+	Rank   *RankDTO `json:"rank,omitempty"`
+	Suit   *SuitDTO `json:"suit,omitempty"`
+	FaceUp bool     `json:"face_up"`
+}
+
+// MarshalJSON hides the rank and suit of face-down cards
+func (c CardDTO) MarshalJSON() ([]byte, error) {
+	out := cardJSON{FaceUp: c.FaceUp}
+	if c.FaceUp {
+		out.Rank, out.Suit = &c.Rank, &c.Suit
+	}
+	return json.Marshal(out)
+}
+
+// UnmarshalJSON decodes a card; face-down cards come back with zero rank and suit
+func (c *CardDTO) UnmarshalJSON(data []byte) error {
+	var in cardJSON
+	if err := json.Unmarshal(data, &in); err != nil {
+		return err
+	}
+	*c = CardDTO{FaceUp: in.FaceUp}
+	if in.FaceUp {
+		if in.Rank == nil || in.Suit == nil {
+			return errors.New("face-up card needs a rank and suit")
+		}
+		c.Rank, c.Suit = *in.Rank, *in.Suit
+	}
+	return nil
+}
+
+// viewFields has GameViewDTO's fields but not its methods, so encoding it doesn't recurse
+type viewFields GameViewDTO
+
+type viewJSON struct {
+	Schema int `json:"schema"`
+	viewFields
+	ElapsedMS int64 `json:"elapsed_ms"`
+}
+
+// MarshalJSON encodes the view in the ViewSchemaVersion format
+func (v GameViewDTO) MarshalJSON() ([]byte, error) {
+	return json.Marshal(viewJSON{
+		Schema:     ViewSchemaVersion,
+		viewFields: viewFields(v),
+		ElapsedMS:  v.Elapsed.Milliseconds(),
+	})
+}
+
+// UnmarshalJSON decodes a view, rejecting schema versions newer than this build understands
+func (v *GameViewDTO) UnmarshalJSON(data []byte) error {
+	var in viewJSON
+	if err := json.Unmarshal(data, &in); err != nil {
+		return err
+	}
+	if in.Schema < 1 || in.Schema > ViewSchemaVersion {
+		return ViewSchemaError{Schema: in.Schema}
+	}
+	*v = GameViewDTO(in.viewFields)
+	v.Elapsed = time.Duration(in.ElapsedMS) * time.Millisecond
+	return nil
+}
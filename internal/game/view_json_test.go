@@ -0,0 +1,111 @@
+package game
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/staylor11x/spider-solitaire/internal/deck"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestViewJSON_Encoding(t *testing.T) {
+	view := GameViewDTO{
+		Tableau: []PileDTO{{Cards: []CardDTO{
+			{Rank: RankDTO(deck.King), Suit: SuitDTO(deck.Hearts), FaceUp: false},
+			{Rank: RankDTO(deck.Ten), Suit: SuitDTO(deck.Spades), FaceUp: true},
+		}}, {Cards: []CardDTO{}}},
+		StockCount: 40,
+		SuitCount:  2,
+		Seed:       1 << 60,
+		Score:      499,
+		Deals:      1,
+		Elapsed:    1500 * time.Millisecond,
+	}
+
+	data, err := json.Marshal(view)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"schema": 1,
+		"tableau": [
+			{"cards": [{"face_up": false}, {"rank": "10", "suit": "spades", "face_up": true}]},
+			{"cards": []}
+		],
+		"stock_count": 40, "completed_count": 0, "won": false, "lost": false,
+		"suit_count": 2, "seed": "1152921504606846976", "score": 499,
+		"moves": 0, "deals": 1, "undos": 0, "elapsed_ms": 1500
+	}`, string(data))
+}
+
+func TestViewJSON_RoundTrip(t *testing.T) {
+	g, err := DealSeededGame(deck.FourSuits, 31)
+	require.NoError(t, err)
+	g.SetClock(newFakeClock())
+	require.NoError(t, g.DealRow())
+	view := g.View()
+
+	data, err := json.Marshal(view)
+	require.NoError(t, err)
+
+	var decoded GameViewDTO
+	require.NoError(t, json.Unmarshal(data, &decoded))
+
+	// face-down cards lose their identity on the wire, everything else survives
+	for i := range view.Tableau {
+		for j, c := range view.Tableau[i].Cards {
+			if !c.FaceUp {
+				view.Tableau[i].Cards[j] = CardDTO{}
+			}
+		}
+	}
+	assert.Equal(t, view, decoded)
+}
+
+func TestViewJSON_CardTagsMatchTheWire(t *testing.T) {
+	dto, wire := reflect.TypeFor[CardDTO](), reflect.TypeFor[cardJSON]()
+	require.Equal(t, wire.NumField(), dto.NumField())
+	for i := range dto.NumField() {
+		name, _, _ := strings.Cut(wire.Field(i).Tag.Get("json"), ",")
+		assert.Equal(t, name, dto.Field(i).Tag.Get("json"), dto.Field(i).Name)
+	}
+
+	data, err := json.Marshal(CardDTO{Rank: RankDTO(deck.Ace), Suit: SuitDTO(deck.Spades), FaceUp: true})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"rank": "A", "suit": "spades", "face_up": true}`, string(data))
+}
+
+func TestViewJSON_RejectsUnknownSchema(t *testing.T) {
+	var v GameViewDTO
+	err := json.Unmarshal([]byte(`{"schema": 99}`), &v)
+	assert.ErrorAs(t, err, &ViewSchemaError{})
+
+	err = json.Unmarshal([]byte(`{"tableau": []}`), &v)
+	assert.ErrorAs(t, err, &ViewSchemaError{}, "a missing schema is not a view")
+}
+
+func TestRankAndSuitText(t *testing.T) {
+	for r := RankDTO(deck.Ace); r <= RankDTO(deck.King); r++ {
+		text, err := r.MarshalText()
+		require.NoError(t, err)
+		var back RankDTO
+		require.NoError(t, back.UnmarshalText(text))
+		assert.Equal(t, r, back)
+	}
+	for s := SuitDTO(deck.Spades); s <= SuitDTO(deck.Clubs); s++ {
+		text, err := s.MarshalText()
+		require.NoError(t, err)
+		var back SuitDTO
+		require.NoError(t, back.UnmarshalText(text))
+		assert.Equal(t, s, back)
+	}
+
+	_, err := RankDTO(0).MarshalText()
+	assert.Error(t, err)
+	var r RankDTO
+	assert.Error(t, r.UnmarshalText([]byte("11")))
+	var s SuitDTO
+	assert.Error(t, s.UnmarshalText([]byte("stars")))
+}
@@ -1,10 +1,12 @@
 package game
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/staylor11x/spider-solitaire/internal/deck"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestGameStateView_FidelityAndDefensiveCopies(t *testing.T) {
@@ -81,6 +83,30 @@ func TestGameStateView_FidelityAndDefensiveCopies(t *testing.T) {
 	assert.False(t, view2.Tableau[0].Cards[1].FaceUp)
 }
 
+func TestGameStateView_ExposesDeal(t *testing.T) {
+	g, err := DealSeededGame(deck.TwoSuits, 1234)
+	assert.NoError(t, err)
+
+	view := g.View()
+	assert.Equal(t, 2, view.SuitCount)
+	assert.Equal(t, uint64(1234), view.Seed)
+}
+
+func TestGameStateView_CompletedSuits(t *testing.T) {
+	g, err := DealSeededGame(deck.FourSuits, 1)
+	require.NoError(t, err)
+	assert.Empty(t, g.View().CompletedSuits)
+
+	g.Completed = append(g.Completed, CompletedRun(deck.Hearts), CompletedRun(deck.Clubs))
+	view := g.View()
+	assert.Equal(t, 2, view.CompletedCount)
+	assert.Equal(t, []SuitDTO{SuitDTO(deck.Hearts), SuitDTO(deck.Clubs)}, view.CompletedSuits)
+
+	data, err := json.Marshal(view)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"completed_suits":["hearts","clubs"]`)
+}
+
 func TestGameStateView_EmptyState(t *testing.T) {
 	var g GameState // nothing
 
@@ -98,3 +124,22 @@ func TestGameStateView_EmptyState(t *testing.T) {
 		assert.Len(t, view.Tableau[i].Cards, 0)
 	}
 }
+
+func TestGameViewDTO_Masked(t *testing.T) {
+	g, err := DealSeededGame(deck.FourSuits, 99)
+	assert.NoError(t, err)
+	view := g.View()
+
+	masked := view.Masked()
+	for i, pile := range masked.Tableau {
+		for j, c := range pile.Cards {
+			if c.FaceUp {
+				assert.Equal(t, view.Tableau[i].Cards[j], c, "visible cards are kept")
+			} else {
+				assert.Equal(t, CardDTO{}, c, "hidden cards are cleared")
+			}
+		}
+	}
+	assert.NotEqual(t, view.Tableau[0].Cards[0], masked.Tableau[0].Cards[0], "the original view is untouched")
+	assert.Equal(t, view.StockCount, masked.StockCount)
+}
@@ -0,0 +1,45 @@
+// Package jsonlog reads and writes the player's record files: a JSON object holding a
+// schema version and one list of records. Each file picks its own version, list name
+// and record type, and checks its records itself.
+package jsonlog
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// Write encodes records as indented JSON, under key and beside the schema version
+func Write[T any](w io.Writer, version int, key string, records []T) error {
+	if records == nil {
+		records = []T{} // an empty log is written as an empty list rather than null
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(map[string]any{"version": version, key: records})
+}
+
+// Read decodes the records under key from a file written by Write. A file with a
+// different schema version is refused; a missing list reads as no records.
+func Read[T any](r io.Reader, version int, key string) ([]T, error) {
+	var file map[string]json.RawMessage
+	if err := json.NewDecoder(r).Decode(&file); err != nil {
+		return nil, err
+	}
+	var v int
+	if raw, ok := file["version"]; ok {
+		if err := json.Unmarshal(raw, &v); err != nil {
+			return nil, fmt.Errorf("bad version: %v", err)
+		}
+	}
+	if v != version {
+		return nil, fmt.Errorf("unsupported version %d", v)
+	}
+	var records []T
+	if raw, ok := file[key]; ok {
+		if err := json.Unmarshal(raw, &records); err != nil {
+			return nil, err
+		}
+	}
+	return records, nil
+}
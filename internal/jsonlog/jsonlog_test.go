@@ -0,0 +1,49 @@
+package jsonlog
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type record struct {
+	Name string `json:"name"`
+}
+
+func TestWriteRead_RoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, 3, "records", []record{{"a"}, {"b"}}))
+	assert.JSONEq(t, `{"version": 3, "records": [{"name": "a"}, {"name": "b"}]}`, buf.String())
+
+	got, err := Read[record](&buf, 3, "records")
+	require.NoError(t, err)
+	assert.Equal(t, []record{{"a"}, {"b"}}, got)
+}
+
+func TestWrite_EmptyList(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, Write[record](&buf, 1, "records", nil))
+	assert.JSONEq(t, `{"version": 1, "records": []}`, buf.String())
+}
+
+func TestRead(t *testing.T) {
+	got, err := Read[record](strings.NewReader(`{"version": 1}`), 1, "records")
+	require.NoError(t, err)
+	assert.Empty(t, got, "no list means no records")
+
+	for name, input := range map[string]string{
+		"not json":        "records",
+		"newer":           `{"version": 2, "records": []}`,
+		"missing version": `{"records": []}`,
+		"bad version":     `{"version": "1"}`,
+		"bad records":     `{"version": 1, "records": {}}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := Read[record](strings.NewReader(input), 1, "records")
+			assert.Error(t, err)
+		})
+	}
+}
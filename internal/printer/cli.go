@@ -3,21 +3,108 @@ package printer
 import (
 	"fmt"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/staylor11x/spider-solitaire/internal/deck"
 	"github.com/staylor11x/spider-solitaire/internal/game"
 )
 
 type Options struct {
-	UnicodeSuits bool // if false, default to ASCII
+	UnicodeSuits bool // if false, default to ASCII (S/H/D/C, T for ten)
+	Color        bool // color red suits with ANSI escapes
+	Compact      bool // one line per pile instead of columns
 }
 
+const (
+	faceDownLabel = "##"
+	emptyLabel    = "--"
+	columnWidth   = 5 // widest label ("10♠") plus spacing
+	gutterWidth   = 3 // row numbers down the left edge
+
+	ansiRed   = "\x1b[31m"
+	ansiReset = "\x1b[0m"
+)
+
+// Render draws the game for a terminal. By default the piles are columns the way
+// they sit on the table, with card positions down the left so moves can be read
+// straight off the screen; Compact gives the older one-line-per-pile layout.
 func Render(view game.GameViewDTO, opts Options) string {
+	if opts.Compact {
+		return renderCompact(view, opts)
+	}
+	return renderColumns(view, opts)
+}
+
+func renderColumns(view game.GameViewDTO, opts Options) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "Deal: %d (%d-suit) | Score: %d | Moves: %d (deals %d, undos %d) | Time: %s\n",
+		view.Seed, view.SuitCount, view.Score, view.Moves, view.Deals, view.Undos, formatElapsed(view))
+
+	// pile headers
+	var header strings.Builder
+	header.WriteString(strings.Repeat(" ", gutterWidth))
+	for i := range view.Tableau {
+		writeCell(&header, fmt.Sprintf("%d", i), "")
+	}
+	b.WriteString(strings.TrimRight(header.String(), " "))
+	b.WriteByte('\n')
+
+	// rows, bottom card first, one column per pile
+	height := 1 // keep a row for the empty-pile markers
+	for _, pile := range view.Tableau {
+		height = max(height, len(pile.Cards))
+	}
+	for row := range height {
+		var line strings.Builder
+		fmt.Fprintf(&line, "%-*d", gutterWidth, row)
+		for _, pile := range view.Tableau {
+			switch {
+			case row < len(pile.Cards):
+				c := pile.Cards[row]
+				writeCell(&line, formatCard(c, opts), cardColor(c, opts))
+			case row == 0:
+				writeCell(&line, emptyLabel, "")
+			default:
+				writeCell(&line, "", "")
+			}
+		}
+		b.WriteString(strings.TrimRight(line.String(), " "))
+		b.WriteByte('\n')
+	}
+
+	// footer
+	fmt.Fprintf(&b, "Stock: %d (%d deals left) | Completed: %d/%d",
+		view.StockCount, view.StockCount/game.TableauPiles, view.CompletedCount, game.TotalRunsToWin)
+	switch {
+	case view.Won:
+		b.WriteString(" | WON")
+	case view.Lost:
+		b.WriteString(" | LOST")
+	}
+	b.WriteByte('\n')
+
+	return b.String()
+}
+
+// writeCell pads label to the column width, wrapping it in color when one is given
+func writeCell(b *strings.Builder, label, color string) {
+	pad := columnWidth - utf8.RuneCountInString(label)
+	if color != "" {
+		label = color + label + ansiReset
+	}
+	b.WriteString(label)
+	b.WriteString(strings.Repeat(" ", max(pad, 1)))
+}
+
+func renderCompact(view game.GameViewDTO, opts Options) string {
 	var b strings.Builder
 
 	// header
-	fmt.Fprintf(&b, "Stock: %d | Completed: %d | Won %v | Lost: %v \n",
-		view.StockCount, view.CompletedCount, view.Won, view.Lost)
+	fmt.Fprintf(&b, "Deal: %d (%d-suit) | Score: %d | Stock: %d | Completed: %d | Won %v | Lost: %v \n",
+		view.Seed, view.SuitCount, view.Score, view.StockCount, view.CompletedCount, view.Won, view.Lost)
+	fmt.Fprintf(&b, "Moves: %d | Deals: %d | Undos: %d | Time: %s\n",
+		view.Moves, view.Deals, view.Undos, formatElapsed(view))
 
 	// Tableau: one line per pile, bottom->top order
 	for i, pile := range view.Tableau {
@@ -26,7 +113,11 @@ func Render(view game.GameViewDTO, opts Options) string {
 			if j > 0 {
 				b.WriteString(", ")
 			}
-			b.WriteString(formatCard(c, opts))
+			label := formatCard(c, opts)
+			if color := cardColor(c, opts); color != "" {
+				label = color + label + ansiReset
+			}
+			b.WriteString(label)
 		}
 		b.WriteByte('\n')
 	}
@@ -34,10 +125,36 @@ func Render(view game.GameViewDTO, opts Options) string {
 	return b.String()
 }
 
+func formatElapsed(view game.GameViewDTO) string {
+	secs := int(view.Elapsed.Seconds())
+	return fmt.Sprintf("%d:%02d", secs/60, secs%60)
+}
+
+// formatCard returns a short label such as "Q♠", or "QS" in ASCII mode
 func formatCard(c game.CardDTO, opts Options) string {
 	if !c.FaceUp {
-		return "##"
+		return faceDownLabel
 	}
 	card := deck.Card{Suit: deck.Suit(c.Suit), Rank: deck.Rank(c.Rank)}
-	return fmt.Sprintf("%s%s", card.RankName(), card.SuitSymbol())
+	if opts.UnicodeSuits {
+		return card.RankSymbol() + card.SuitSymbol()
+	}
+	rank := card.RankSymbol()
+	if card.Rank == deck.Ten {
+		rank = "T" // keep ASCII labels two characters wide
+	}
+	return rank + card.Suit.String()[:1]
+}
+
+// cardColor returns the ANSI color for a card, or "" when it should be left plain
+func cardColor(c game.CardDTO, opts Options) string {
+	if !opts.Color || !c.FaceUp {
+		return ""
+	}
+	switch deck.Suit(c.Suit) {
+	case deck.Hearts, deck.Diamonds:
+		return ansiRed
+	default:
+		return ""
+	}
 }
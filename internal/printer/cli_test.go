@@ -0,0 +1,79 @@
+package printer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/staylor11x/spider-solitaire/internal/deck"
+	"github.com/staylor11x/spider-solitaire/internal/game"
+	"github.com/stretchr/testify/assert"
+)
+
+func card(s deck.Suit, r deck.Rank, faceUp bool) game.CardDTO {
+	return game.CardDTO{Suit: game.SuitDTO(s), Rank: game.RankDTO(r), FaceUp: faceUp}
+}
+
+// testView has a face-down card under a ten, a lone red queen, and eight empty piles
+func testView() game.GameViewDTO {
+	view := game.GameViewDTO{
+		Tableau:        make([]game.PileDTO, game.TableauPiles),
+		StockCount:     30,
+		CompletedCount: 2,
+		SuitCount:      2,
+		Seed:           7,
+		Score:          480,
+	}
+	view.Tableau[0].Cards = []game.CardDTO{card(deck.Spades, deck.King, false), card(deck.Spades, deck.Ten, true)}
+	view.Tableau[1].Cards = []game.CardDTO{card(deck.Hearts, deck.Queen, true)}
+	return view
+}
+
+func TestRender_Columns(t *testing.T) {
+	out := Render(testView(), Options{})
+	lines := strings.Split(strings.TrimSuffix(out, "\n"), "\n")
+
+	assert.Equal(t, []string{
+		"Deal: 7 (2-suit) | Score: 480 | Moves: 0 (deals 0, undos 0) | Time: 0:00",
+		"   0    1    2    3    4    5    6    7    8    9",
+		"0  ##   QH   --   --   --   --   --   --   --   --",
+		"1  TS",
+		"Stock: 30 (3 deals left) | Completed: 2/8",
+	}, lines)
+}
+
+func TestRender_UnicodeAndColor(t *testing.T) {
+	out := Render(testView(), Options{UnicodeSuits: true, Color: true})
+	assert.Contains(t, out, "10♠")
+	assert.Contains(t, out, ansiRed+"Q♥"+ansiReset, "red suits should be colored")
+	assert.NotContains(t, out, ansiRed+"10♠", "black suits stay plain")
+
+	// columns stay aligned whatever the label width or escapes
+	lines := strings.Split(out, "\n")
+	plain := strings.NewReplacer(ansiRed, "", ansiReset, "").Replace(lines[2])
+	assert.True(t, strings.HasPrefix(plain, "0  ##   Q♥   --   --"), plain)
+	assert.Equal(t, "1  10♠", lines[3])
+}
+
+func TestRender_ASCIIHasNoUnicodeSuits(t *testing.T) {
+	out := Render(testView(), Options{})
+	assert.NotContains(t, out, "♠")
+	assert.NotContains(t, out, "♥")
+	assert.NotContains(t, out, "\x1b[", "no escapes unless Color is set")
+}
+
+func TestRender_Compact(t *testing.T) {
+	out := Render(testView(), Options{Compact: true})
+	assert.Contains(t, out, "Stock: 30 | Completed: 2")
+	assert.Contains(t, out, "p0: ##, TS\n")
+	assert.Contains(t, out, "p1: QH\n")
+	assert.Contains(t, out, "p2: \n")
+}
+
+func TestRender_ShowsResult(t *testing.T) {
+	view := testView()
+	view.Won = true
+	assert.Contains(t, Render(view, Options{}), "Completed: 2/8 | WON\n")
+
+	view.Won, view.Lost = false, true
+	assert.Contains(t, Render(view, Options{}), "| LOST\n")
+}
@@ -0,0 +1,219 @@
+// Package protocol drives the engine over a line-based text protocol, in the spirit
+// of UCI for chess, so bots in any language can play by running the CLI as a subprocess.
+//
+// The client writes one command per line; the engine answers every command with
+// exactly one line of JSON. On start the engine sends a greeting:
+//
+//	{"ok":true,"protocol":1}
+//
+// Commands:
+//
+//	newgame [suits=1|2|4] [seed=N]   deal a new game (same suits and a random seed by default)
+//	move <src> <start> <dst>         move a sequence; "move src:start>dst" also works
+//	deal                             deal a row from the stock
+//	undo                             undo the last move or deal
+//	state                            report the current state
+//	legalmoves                       list legal moves in move notation ("3:5>7", "deal")
+//	quit                             end the session
+//
+// Successful game commands reply with {"ok":true,"state":{...}} where state is the
+// game.GameViewDTO JSON encoding; actions also list the engine events they caused.
+// Failures reply with {"ok":false,"error":{"code":"...","message":"..."}}; codes come
+// from game.ErrorCode, plus the protocol's own unknown_command and bad_arguments.
+package protocol
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/staylor11x/spider-solitaire/internal/deck"
+	"github.com/staylor11x/spider-solitaire/internal/game"
+)
+
+// Version is reported in the greeting and bumped on incompatible changes
+const Version = 1
+
+// Protocol-level error codes, for problems that never reach the engine
+const (
+	CodeUnknownCommand = "unknown_command"
+	CodeBadArguments   = "bad_arguments"
+	CodeInternal       = "internal"
+)
+
+// Response is one reply line
+type Response struct {
+	OK       bool              `json:"ok"`
+	Protocol int               `json:"protocol,omitempty"` // greeting only
+	State    *game.GameViewDTO `json:"state,omitempty"`
+	Events   []string          `json:"events,omitempty"` // kinds of the events an action caused, in order
+	Moves    *[]string         `json:"moves,omitempty"`  // legalmoves only; a pointer so an empty list is still sent
+	Error    *Error            `json:"error,omitempty"`
+}
+
+// Error describes a rejected command
+type Error struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// Engine holds the game a protocol session is playing
+type Engine struct {
+	game *game.GameState
+	quit bool
+}
+
+// NewEngine starts a session on g
+func NewEngine(g *game.GameState) *Engine {
+	return &Engine{game: g}
+}
+
+// Serve greets the client, then answers each command line until quit or end of input
+func Serve(r io.Reader, w io.Writer, g *game.GameState) error {
+	e := NewEngine(g)
+	enc := json.NewEncoder(w) // one compact object per line
+	if err := enc.Encode(Response{OK: true, Protocol: Version}); err != nil {
+		return err
+	}
+
+	sc := bufio.NewScanner(r)
+	for !e.quit && sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" {
+			continue
+		}
+		if err := enc.Encode(e.Handle(line)); err != nil {
+			return err
+		}
+	}
+	return sc.Err()
+}
+
+// Handle executes one command line and returns its reply
+func (e *Engine) Handle(line string) Response {
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return failure(CodeUnknownCommand, "empty command")
+	}
+	args := fields[1:]
+
+	switch fields[0] {
+	case "newgame":
+		return e.newGame(args)
+	case "move":
+		m, err := parseMoveArgs(args)
+		if err != nil {
+			return failure(CodeBadArguments, err.Error())
+		}
+		return e.act(func() error { return e.game.Apply(m) })
+	case "deal":
+		return e.act(e.game.DealRow)
+	case "undo":
+		return e.act(e.game.Undo)
+	case "state":
+		return e.state(nil)
+	case "legalmoves":
+		legal := e.game.LegalMoves()
+		moves := make([]string, len(legal))
+		for i, m := range legal {
+			moves[i] = m.String()
+		}
+		return Response{OK: true, Moves: &moves}
+	case "quit":
+		e.quit = true
+		return Response{OK: true}
+	default:
+		return failure(CodeUnknownCommand, fmt.Sprintf("unknown command %q", fields[0]))
+	}
+}
+
+// act runs an engine action and reports the new state and its events, or the engine's error
+func (e *Engine) act(action func() error) Response {
+	if err := action(); err != nil {
+		return engineFailure(err)
+	}
+	events := e.game.LastEvents()
+	kinds := make([]string, len(events))
+	for i, ev := range events {
+		kinds[i] = ev.Kind.String()
+	}
+	return e.state(kinds)
+}
+
+func (e *Engine) state(events []string) Response {
+	view := e.game.View()
+	return Response{OK: true, State: &view, Events: events}
+}
+
+func (e *Engine) newGame(args []string) Response {
+	suits := e.game.SuitCount
+	seed := deck.RandomSeed()
+	for _, arg := range args {
+		key, value, ok := strings.Cut(arg, "=")
+		if !ok {
+			return failure(CodeBadArguments, fmt.Sprintf("expected key=value, got %q", arg))
+		}
+		switch key {
+		case "suits":
+			n, err := strconv.Atoi(value)
+			if err != nil || !deck.SuitCount(n).Valid() {
+				return failure(CodeBadArguments, "suits must be 1, 2 or 4")
+			}
+			suits = deck.SuitCount(n)
+		case "seed":
+			n, err := strconv.ParseUint(value, 10, 64)
+			if err != nil {
+				return failure(CodeBadArguments, "seed must be an unsigned integer")
+			}
+			seed = n
+		default:
+			return failure(CodeBadArguments, fmt.Sprintf("unknown option %q", key))
+		}
+	}
+
+	g, err := game.DealSeededGame(suits, seed)
+	if err != nil {
+		return engineFailure(err)
+	}
+	e.game = g
+	return e.state(nil)
+}
+
+// parseMoveArgs accepts "src start dst" or a single "src:start>dst"
+func parseMoveArgs(args []string) (game.Move, error) {
+	switch len(args) {
+	case 1:
+		m, err := game.ParseMove(args[0])
+		if err != nil || m.Kind != game.MoveTableau {
+			return game.Move{}, game.ErrInvalidMoveNotation
+		}
+		return m, nil
+	case 3:
+		var idx [3]int
+		for i, a := range args {
+			n, err := strconv.Atoi(a)
+			if err != nil {
+				return game.Move{}, fmt.Errorf("pile and card positions must be integers, got %q", a)
+			}
+			idx[i] = n
+		}
+		return game.TableauMove(idx[0], idx[1], idx[2]), nil
+	default:
+		return game.Move{}, fmt.Errorf("usage: move <src> <start> <dst>")
+	}
+}
+
+func engineFailure(err error) Response {
+	code := game.ErrorCode(err)
+	if code == "" {
+		code = CodeInternal
+	}
+	return failure(code, err.Error())
+}
+
+func failure(code, msg string) Response {
+	return Response{Error: &Error{Code: code, Message: msg}}
+}
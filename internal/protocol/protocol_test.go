@@ -0,0 +1,112 @@
+package protocol
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/staylor11x/spider-solitaire/internal/deck"
+	"github.com/staylor11x/spider-solitaire/internal/game"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestEngine(t *testing.T) *Engine {
+	t.Helper()
+	g, err := game.DealSeededGame(deck.OneSuit, 3)
+	require.NoError(t, err)
+	return NewEngine(g)
+}
+
+func TestServe_OneJSONLinePerCommand(t *testing.T) {
+	g, err := game.DealSeededGame(deck.OneSuit, 3)
+	require.NoError(t, err)
+
+	in := strings.NewReader("newgame suits=2 seed=42\n\nlegalmoves\ndeal\nbogus\nquit\nstate\n")
+	var out bytes.Buffer
+	require.NoError(t, Serve(in, &out, g))
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(t, lines, 6, "greeting plus one reply per command, nothing after quit")
+
+	var replies []map[string]any
+	for _, l := range lines {
+		var r map[string]any
+		require.NoError(t, json.Unmarshal([]byte(l), &r), l)
+		replies = append(replies, r)
+	}
+	assert.Equal(t, float64(Version), replies[0]["protocol"])
+
+	state := replies[1]["state"].(map[string]any)
+	assert.Equal(t, "42", state["seed"])
+	assert.Equal(t, float64(2), state["suit_count"])
+
+	assert.NotEmpty(t, replies[2]["moves"])
+	assert.Equal(t, []any{"RowDealt"}, replies[3]["events"])
+	assert.Equal(t, false, replies[4]["ok"])
+	assert.Equal(t, true, replies[5]["ok"])
+}
+
+func TestHandle_ErrorCodes(t *testing.T) {
+	tests := []struct {
+		line string
+		code string
+	}{
+		{line: "undo", code: "no_history"},
+		{line: "move 0 0 0", code: "same_pile_move"},
+		{line: "move 0 0 1", code: "card_face_down"},
+		{line: "move 0 5 1", code: "destination_not_accepting"},
+		{line: "move 0:5", code: CodeBadArguments},
+		{line: "move a b c", code: CodeBadArguments},
+		{line: "newgame suits=3", code: CodeBadArguments},
+		{line: "newgame colour=red", code: CodeBadArguments},
+		{line: "fly", code: CodeUnknownCommand},
+	}
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			r := newTestEngine(t).Handle(tt.line)
+			assert.False(t, r.OK)
+			require.NotNil(t, r.Error)
+			assert.Equal(t, tt.code, r.Error.Code)
+			assert.NotEmpty(t, r.Error.Message)
+		})
+	}
+}
+
+func TestHandle_MoveReportsStateAndEvents(t *testing.T) {
+	e := newTestEngine(t)
+
+	// play the first legal tableau move in both notations
+	for _, form := range []string{"%d %d %d", "%d:%d>%d"} {
+		var m game.Move
+		found := false
+		for _, lm := range e.game.LegalMoves() {
+			if lm.Kind == game.MoveTableau {
+				m, found = lm, true
+				break
+			}
+		}
+		require.True(t, found, "no tableau move to play")
+		before := e.game.View().Tableau
+		moved := len(before[m.Src].Cards) - m.Start
+
+		r := e.Handle("move " + fmt.Sprintf(form, m.Src, m.Start, m.Dst))
+		require.True(t, r.OK, "%+v", r.Error)
+		require.NotNil(t, r.State)
+		assert.Equal(t, "MoveApplied", r.Events[0])
+		assert.Len(t, r.State.Tableau[m.Src].Cards, m.Start, "the run left its source pile")
+		assert.Len(t, r.State.Tableau[m.Dst].Cards, len(before[m.Dst].Cards)+moved, "and landed on the destination")
+	}
+	assert.Equal(t, 2, e.game.Moves)
+}
+
+func TestHandle_LegalMovesAlwaysSendsList(t *testing.T) {
+	e := NewEngine(&game.GameState{}) // empty table, empty stock: nothing to do
+	r := e.Handle("legalmoves")
+
+	data, err := json.Marshal(r)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"ok": true, "moves": []}`, string(data))
+}
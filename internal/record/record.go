@@ -0,0 +1,259 @@
+// Package record reads and writes spider game records, a PGN-like text format
+// that makes a game shareable as a single file:
+//
+//	[Suits "2"]
+//	[Seed "12345"]
+//	[Variant "Spider"]
+//	[Date "2026.10.16"]
+//	[Result "*"]
+//
+//	3:5>7
+//	deal
+//	undo
+//
+// The header identifies the deal; each following token is one action, in order.
+// Moves use game.Move notation ("src:start>dst" or "deal") plus "undo" and
+// "auto(src:start>dst,...)", an auto-complete with the moves it made. A bare "auto"
+// finishes the game with whatever line GameState.AutoComplete finds.
+// A ';' starts a comment that runs to the end of the line.
+package record
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/staylor11x/spider-solitaire/internal/deck"
+	"github.com/staylor11x/spider-solitaire/internal/game"
+)
+
+// Result tag values, following PGN's "*" for a game still in progress
+const (
+	ResultWon        = "won"
+	ResultLost       = "lost"
+	ResultInProgress = "*"
+)
+
+// DefaultVariant is written when a record names no variant
+const DefaultVariant = "Spider"
+
+// DateLayout is the PGN date format used by the Date tag
+const DateLayout = "2006.01.02"
+
+const (
+	undoToken = "undo"
+	autoToken = "auto"
+)
+
+// Record is a parsed or recorded game: the deal it was played on and every action taken
+type Record struct {
+	Suits   deck.SuitCount
+	Seed    uint64
+	Variant string
+	Date    string
+	Result  string
+	Steps   []Step
+}
+
+// Step is one recorded action: a move, a deal, an undo or an auto-complete
+type Step struct {
+	Undo      bool
+	Auto      bool
+	AutoMoves []game.Move // the moves an auto-complete made; empty lets Replay search for them
+	Move      game.Move   // unused when Undo or Auto is set
+	Line      int         // line in the source text, zero for steps recorded live
+}
+
+// String renders the step in record notation
+func (s Step) String() string {
+	switch {
+	case s.Undo:
+		return undoToken
+	case s.Auto && len(s.AutoMoves) == 0:
+		return autoToken
+	case s.Auto:
+		moves := make([]string, len(s.AutoMoves))
+		for i, m := range s.AutoMoves {
+			moves[i] = m.String()
+		}
+		return autoToken + "(" + strings.Join(moves, ",") + ")"
+	}
+	return s.Move.String()
+}
+
+// ParseError reports malformed record text
+type ParseError struct {
+	Line int // zero when the problem is not tied to one line, e.g. a missing tag
+	Msg  string
+}
+
+func (e ParseError) Error() string {
+	if e.Line == 0 {
+		return "record: " + e.Msg
+	}
+	return fmt.Sprintf("record: line %d: %s", e.Line, e.Msg)
+}
+
+// Write renders the record: header tags, a blank line, then one action per line
+func (r *Record) Write(w io.Writer) error {
+	variant := r.Variant
+	if variant == "" {
+		variant = DefaultVariant
+	}
+	result := r.Result
+	if result == "" {
+		result = ResultInProgress
+	}
+
+	bw := bufio.NewWriter(w)
+	writeTag(bw, "Suits", strconv.Itoa(int(r.Suits)))
+	writeTag(bw, "Seed", strconv.FormatUint(r.Seed, 10))
+	writeTag(bw, "Variant", variant)
+	if r.Date != "" {
+		writeTag(bw, "Date", r.Date)
+	}
+	writeTag(bw, "Result", result)
+	bw.WriteString("\n")
+	for _, s := range r.Steps {
+		bw.WriteString(s.String())
+		bw.WriteString("\n")
+	}
+	return bw.Flush()
+}
+
+func writeTag(w *bufio.Writer, name, value string) {
+	fmt.Fprintf(w, "[%s %s]\n", name, strconv.Quote(value))
+}
+
+// String returns the record text, as written by Write
+func (r *Record) String() string {
+	var sb strings.Builder
+	_ = r.Write(&sb) // writing to a strings.Builder cannot fail
+	return sb.String()
+}
+
+// Parse reads a record. Suits and Seed are required; unknown tags are ignored.
+// Only the syntax is checked here, Replay checks that the moves are legal.
+func Parse(r io.Reader) (*Record, error) {
+	rec := &Record{Variant: DefaultVariant, Result: ResultInProgress}
+	var haveSuits, haveSeed bool
+
+	sc := bufio.NewScanner(r)
+	line := 0
+	for sc.Scan() {
+		line++
+		text, _, _ := strings.Cut(sc.Text(), ";")
+		text = strings.TrimSpace(text)
+		if text == "" {
+			continue
+		}
+
+		if strings.HasPrefix(text, "[") {
+			if len(rec.Steps) > 0 {
+				return nil, ParseError{Line: line, Msg: "header tag after the move list"}
+			}
+			name, value, err := parseTag(text)
+			if err != nil {
+				return nil, ParseError{Line: line, Msg: err.Error()}
+			}
+			switch name {
+			case "Suits":
+				n, err := strconv.Atoi(value)
+				if err != nil || !deck.SuitCount(n).Valid() {
+					return nil, ParseError{Line: line, Msg: fmt.Sprintf("invalid Suits %q: must be 1, 2 or 4", value)}
+				}
+				rec.Suits, haveSuits = deck.SuitCount(n), true
+			case "Seed":
+				n, err := strconv.ParseUint(value, 10, 64)
+				if err != nil {
+					return nil, ParseError{Line: line, Msg: fmt.Sprintf("invalid Seed %q", value)}
+				}
+				rec.Seed, haveSeed = n, true
+			case "Variant":
+				rec.Variant = value
+			case "Date":
+				rec.Date = value
+			case "Result":
+				rec.Result = value
+			}
+			continue
+		}
+
+		for _, tok := range strings.Fields(text) {
+			step, err := parseStep(tok)
+			if err != nil {
+				return nil, ParseError{Line: line, Msg: fmt.Sprintf("%q: %v", tok, err)}
+			}
+			step.Line = line
+			rec.Steps = append(rec.Steps, step)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+
+	if !haveSuits {
+		return nil, ParseError{Msg: "missing Suits tag"}
+	}
+	if !haveSeed {
+		return nil, ParseError{Msg: "missing Seed tag"}
+	}
+	return rec, nil
+}
+
+// parseTag splits `[Name "value"]`
+func parseTag(text string) (name, value string, err error) {
+	inner, ok := strings.CutSuffix(strings.TrimPrefix(text, "["), "]")
+	if !ok {
+		return "", "", errors.New("unterminated header tag")
+	}
+	name, quoted, ok := strings.Cut(strings.TrimSpace(inner), " ")
+	if !ok || name == "" {
+		return "", "", errors.New(`header tag must look like [Name "value"]`)
+	}
+	value, err = strconv.Unquote(strings.TrimSpace(quoted))
+	if err != nil {
+		return "", "", fmt.Errorf("tag %s: value must be a quoted string", name)
+	}
+	return name, value, nil
+}
+
+func parseStep(tok string) (Step, error) {
+	switch tok {
+	case undoToken:
+		return Step{Undo: true}, nil
+	case autoToken:
+		return Step{Auto: true}, nil
+	}
+	if line, ok := strings.CutPrefix(tok, autoToken+"("); ok {
+		return parseAutoStep(line)
+	}
+	m, err := game.ParseMove(tok)
+	if err != nil {
+		return Step{}, err
+	}
+	return Step{Move: m}, nil
+}
+
+// parseAutoStep reads the moves of "auto(...)", after the opening parenthesis
+func parseAutoStep(line string) (Step, error) {
+	line, ok := strings.CutSuffix(line, ")")
+	if !ok {
+		return Step{}, errors.New("auto-complete moves must end with ')'")
+	}
+	step := Step{Auto: true}
+	for tok := range strings.SplitSeq(line, ",") {
+		m, err := game.ParseMove(tok)
+		if err != nil {
+			return Step{}, err
+		}
+		if m.Kind != game.MoveTableau {
+			return Step{}, errors.New("an auto-complete only moves cards")
+		}
+		step.AutoMoves = append(step.AutoMoves, m)
+	}
+	return step, nil
+}
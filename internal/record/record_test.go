@@ -0,0 +1,106 @@
+package record
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/staylor11x/spider-solitaire/internal/deck"
+	"github.com/staylor11x/spider-solitaire/internal/game"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteAndParse_RoundTrip(t *testing.T) {
+	rec := &Record{
+		Suits:   deck.TwoSuits,
+		Seed:    12345,
+		Variant: DefaultVariant,
+		Date:    "2026.10.16",
+		Result:  ResultInProgress,
+		Steps: []Step{
+			{Move: game.TableauMove(3, 5, 7)},
+			{Move: game.DealMove()},
+			{Undo: true},
+			{Auto: true},
+			{Auto: true, AutoMoves: []game.Move{game.TableauMove(1, 4, 2), game.TableauMove(0, 0, 1)}},
+		},
+	}
+
+	text := rec.String()
+	assert.Equal(t, `[Suits "2"]
+[Seed "12345"]
+[Variant "Spider"]
+[Date "2026.10.16"]
+[Result "*"]
+
+3:5>7
+deal
+undo
+auto
+auto(1:4>2,0:0>1)
+`, text)
+
+	parsed, err := Parse(strings.NewReader(text))
+	require.NoError(t, err)
+	assert.Equal(t, rec.Suits, parsed.Suits)
+	assert.Equal(t, rec.Seed, parsed.Seed)
+	assert.Equal(t, rec.Date, parsed.Date)
+	assert.Equal(t, rec.Result, parsed.Result)
+	require.Len(t, parsed.Steps, 5)
+	for i, s := range parsed.Steps {
+		assert.Equal(t, rec.Steps[i].String(), s.String())
+		assert.Equal(t, rec.Steps[i].AutoMoves, s.AutoMoves)
+		assert.Equal(t, 7+i, s.Line, "steps should remember their source line")
+	}
+}
+
+func TestParse_CommentsAndLooseLayout(t *testing.T) {
+	text := `; shared in the bug channel
+[Seed "9"]   ; any order
+[Suits "1"]
+[Annotator "someone"]
+
+0:4>1 deal ; two on one line
+undo
+`
+	rec, err := Parse(strings.NewReader(text))
+	require.NoError(t, err)
+	assert.Equal(t, deck.OneSuit, rec.Suits)
+	assert.Equal(t, uint64(9), rec.Seed)
+	assert.Equal(t, DefaultVariant, rec.Variant)
+	require.Len(t, rec.Steps, 3)
+	assert.Equal(t, 6, rec.Steps[1].Line)
+	assert.Equal(t, 7, rec.Steps[2].Line)
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		wantLine int
+	}{
+		{name: "missing seed", text: "[Suits \"1\"]\n", wantLine: 0},
+		{name: "missing suits", text: "[Seed \"1\"]\n", wantLine: 0},
+		{name: "bad suits", text: "[Suits \"3\"]\n[Seed \"1\"]\n", wantLine: 1},
+		{name: "bad seed", text: "[Suits \"1\"]\n[Seed \"x\"]\n", wantLine: 2},
+		{name: "unquoted tag", text: "[Suits 1]\n", wantLine: 1},
+		{name: "unterminated tag", text: "[Suits \"1\"\n", wantLine: 1},
+		{name: "bad move", text: "[Suits \"1\"]\n[Seed \"1\"]\n\ndeal\n3-5>7\n", wantLine: 5},
+		{name: "unclosed auto-complete", text: "[Suits \"1\"]\n[Seed \"1\"]\n\nauto(0:1>2\n", wantLine: 4},
+		{name: "deal in an auto-complete", text: "[Suits \"1\"]\n[Seed \"1\"]\n\nauto(0:1>2,deal)\n", wantLine: 4},
+		{name: "tag after moves", text: "[Suits \"1\"]\n[Seed \"1\"]\ndeal\n[Result \"*\"]\n", wantLine: 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(strings.NewReader(tt.text))
+			var pe ParseError
+			require.ErrorAs(t, err, &pe)
+			assert.Equal(t, tt.wantLine, pe.Line)
+		})
+	}
+}
+
+func TestParseErrorMessage(t *testing.T) {
+	assert.Equal(t, "record: line 4: bad", ParseError{Line: 4, Msg: "bad"}.Error())
+	assert.Equal(t, "record: missing Seed tag", ParseError{Msg: "missing Seed tag"}.Error())
+}
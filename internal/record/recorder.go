@@ -0,0 +1,84 @@
+package record
+
+import (
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/staylor11x/spider-solitaire/internal/game"
+)
+
+// ErrGameInProgress is returned when recording would start part way through a game.
+// A record replays from the fresh deal, so earlier actions would be lost.
+var ErrGameInProgress = errors.New("record: game already has actions, start recording on a fresh deal")
+
+// Recorder listens to a game's event stream and writes down every move, deal, undo and auto-complete
+type Recorder struct {
+	game        *game.GameState
+	rec         Record
+	unsubscribe func()
+	autoMoves   int // moves still to come from an auto-complete, which go in its step
+}
+
+// NewRecorder starts recording a freshly dealt game. The Date tag is today's date.
+func NewRecorder(g *game.GameState) (*Recorder, error) {
+	if g.Moves > 0 || g.Deals > 0 || g.Undos > 0 {
+		return nil, ErrGameInProgress
+	}
+	r := &Recorder{
+		game: g,
+		rec: Record{
+			Suits:   g.SuitCount,
+			Seed:    g.Seed,
+			Variant: DefaultVariant,
+			Date:    time.Now().Format(DateLayout),
+		},
+	}
+	r.unsubscribe = g.Subscribe(r.observe)
+	return r, nil
+}
+
+func (r *Recorder) observe(e game.Event) {
+	switch e.Kind {
+	case game.EventAutoCompleted:
+		r.rec.Steps = append(r.rec.Steps, Step{Auto: true})
+		r.autoMoves = e.Count
+	case game.EventMoveApplied, game.EventRowDealt:
+		if r.autoMoves > 0 {
+			r.autoMoves--
+			auto := &r.rec.Steps[len(r.rec.Steps)-1]
+			auto.AutoMoves = append(auto.AutoMoves, e.Move)
+			return
+		}
+		r.rec.Steps = append(r.rec.Steps, Step{Move: e.Move})
+	case game.EventUndoApplied:
+		r.rec.Steps = append(r.rec.Steps, Step{Undo: true})
+	}
+}
+
+// Record returns a copy of the game so far, with Result reflecting the current position
+func (r *Recorder) Record() *Record {
+	rec := r.rec
+	rec.Steps = slices.Clone(r.rec.Steps)
+	for i := range rec.Steps {
+		rec.Steps[i].AutoMoves = slices.Clone(rec.Steps[i].AutoMoves)
+	}
+	rec.Result = resultOf(r.game)
+	return &rec
+}
+
+// Stop detaches the recorder from the game; Record keeps returning what was captured
+func (r *Recorder) Stop() {
+	r.unsubscribe()
+}
+
+func resultOf(g *game.GameState) string {
+	switch {
+	case g.Won:
+		return ResultWon
+	case g.Lost:
+		return ResultLost
+	default:
+		return ResultInProgress
+	}
+}
@@ -0,0 +1,51 @@
+package record
+
+import (
+	"fmt"
+
+	"github.com/staylor11x/spider-solitaire/internal/game"
+)
+
+// IllegalMoveError reports the first step of a record that the engine rejected
+type IllegalMoveError struct {
+	Line int // source line of the step, zero for recorded steps
+	Step Step
+	Err  error // the engine's reason
+}
+
+func (e IllegalMoveError) Error() string {
+	if e.Line == 0 {
+		return fmt.Sprintf("record: illegal %s: %v", e.Step, e.Err)
+	}
+	return fmt.Sprintf("record: line %d: illegal %s: %v", e.Line, e.Step, e.Err)
+}
+
+func (e IllegalMoveError) Unwrap() error {
+	return e.Err
+}
+
+// Replay deals the record's game and plays every step onto it.
+// On an illegal step it returns the game as it stood just before that step,
+// together with an IllegalMoveError, so the position can still be inspected.
+func Replay(rec *Record) (*game.GameState, error) {
+	g, err := game.DealSeededGame(rec.Suits, rec.Seed)
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range rec.Steps {
+		switch {
+		case s.Undo:
+			err = g.Undo()
+		case s.Auto && len(s.AutoMoves) > 0:
+			err = g.AutoCompleteWith(s.AutoMoves)
+		case s.Auto:
+			err = g.AutoComplete()
+		default:
+			err = g.Apply(s.Move)
+		}
+		if err != nil {
+			return g, IllegalMoveError{Line: s.Line, Step: s, Err: err}
+		}
+	}
+	return g, nil
+}
@@ -0,0 +1,121 @@
+package record
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/staylor11x/spider-solitaire/internal/deck"
+	"github.com/staylor11x/spider-solitaire/internal/game"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// playSome makes a handful of real moves (and an undo) on g
+func playSome(t *testing.T, g *game.GameState) {
+	t.Helper()
+	for range 3 {
+		legal := g.LegalMoves()
+		require.NotEmpty(t, legal)
+		require.NoError(t, g.Apply(legal[0]))
+	}
+	require.NoError(t, g.Undo())
+	require.NoError(t, g.DealRow())
+}
+
+func TestRecorder_ReplayReproducesGame(t *testing.T) {
+	g, err := game.DealSeededGame(deck.TwoSuits, 2024)
+	require.NoError(t, err)
+	r, err := NewRecorder(g)
+	require.NoError(t, err)
+
+	playSome(t, g)
+	rec := r.Record()
+	assert.Len(t, rec.Steps, 5)
+	assert.True(t, rec.Steps[3].Undo)
+	assert.Equal(t, ResultInProgress, rec.Result)
+
+	// through text and back, as a pasted record would be
+	parsed, err := Parse(strings.NewReader(rec.String()))
+	require.NoError(t, err)
+
+	replayed, err := Replay(parsed)
+	require.NoError(t, err)
+	assert.Equal(t, g.View().Tableau, replayed.View().Tableau)
+	assert.Equal(t, g.View().StockCount, replayed.View().StockCount)
+	assert.Equal(t, g.Score, replayed.Score)
+}
+
+func TestRecorder_AutoCompleteIsOneStepWithItsMoves(t *testing.T) {
+	// one run left, dealt out across three piles
+	g := game.AlmostWon()
+	g.Tableau = game.Tableau{}
+	for r := deck.King; r >= deck.Ace; r-- {
+		g.Tableau.Piles[int(r)%3].AddCard(deck.Card{Suit: deck.Spades, Rank: r}, true)
+	}
+	start := g.Clone()
+	require.True(t, g.CanAutoComplete())
+	r, err := NewRecorder(g)
+	require.NoError(t, err)
+
+	require.NoError(t, g.AutoComplete())
+	require.NoError(t, g.Undo())
+	require.NoError(t, g.Redo())
+	steps := r.Record().Steps
+	require.Len(t, steps, 3)
+	assert.True(t, steps[0].Auto)
+	assert.True(t, steps[1].Undo)
+	assert.Equal(t, steps[0], steps[2], "redo records the same auto-complete")
+
+	// the recorded line plays again without searching
+	parsed, err := parseStep(steps[0].String())
+	require.NoError(t, err)
+	require.NoError(t, start.AutoCompleteWith(parsed.AutoMoves))
+	assert.True(t, start.Won)
+}
+
+func TestRecorder_Stop(t *testing.T) {
+	g, err := game.DealSeededGame(deck.OneSuit, 1)
+	require.NoError(t, err)
+	r, err := NewRecorder(g)
+	require.NoError(t, err)
+
+	require.NoError(t, g.DealRow())
+	r.Stop()
+	require.NoError(t, g.DealRow())
+	assert.Len(t, r.Record().Steps, 1, "actions after Stop should not be recorded")
+}
+
+func TestNewRecorder_RejectsGameInProgress(t *testing.T) {
+	g, err := game.DealSeededGame(deck.OneSuit, 1)
+	require.NoError(t, err)
+	require.NoError(t, g.DealRow())
+
+	_, err = NewRecorder(g)
+	assert.ErrorIs(t, err, ErrGameInProgress)
+}
+
+func TestReplay_ReportsFirstIllegalMove(t *testing.T) {
+	text := `[Suits "1"]
+[Seed "7"]
+
+deal
+undo
+undo
+deal
+`
+	rec, err := Parse(strings.NewReader(text))
+	require.NoError(t, err)
+
+	g, err := Replay(rec)
+	var ime IllegalMoveError
+	require.ErrorAs(t, err, &ime)
+	assert.Equal(t, 6, ime.Line)
+	assert.True(t, ime.Step.Undo)
+	assert.ErrorIs(t, err, game.ErrNoHistory)
+	assert.Contains(t, err.Error(), "line 6")
+
+	// the game is left just before the illegal step
+	require.NotNil(t, g)
+	assert.Equal(t, 1, g.Deals)
+	assert.Equal(t, 1, g.Undos)
+}
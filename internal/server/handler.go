@@ -0,0 +1,286 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/staylor11x/spider-solitaire/internal/deck"
+	"github.com/staylor11x/spider-solitaire/internal/game"
+	"github.com/staylor11x/spider-solitaire/internal/logger"
+)
+
+// API error codes for failures outside the engine; engine failures use game.ErrorCode
+const (
+	CodeBadRequest      = "bad_request"
+	CodeBodyTooLarge    = "body_too_large"
+	CodeNotFound        = "session_not_found"
+	CodeTooManySessions = "too_many_sessions"
+	CodeInternal        = "internal"
+)
+
+// maxBodyBytes caps a request body; the largest real one is a few dozen bytes
+const maxBodyBytes = 4 << 10
+
+// GameResponse is the body returned by every game endpoint
+type GameResponse struct {
+	ID     string           `json:"id"`
+	State  game.GameViewDTO `json:"state"`
+	Events []string         `json:"events,omitempty"` // kinds of the events an action caused
+}
+
+// CreateRequest is the body of POST /games. Both fields are optional.
+type CreateRequest struct {
+	Suits int     `json:"suits"`                 // 1, 2 or 4; 1 when omitted
+	Seed  *uint64 `json:"seed,string,omitempty"` // deal number as a string, as in the view; random when omitted
+}
+
+// MoveRequest is the body of POST /games/{id}/moves
+type MoveRequest struct {
+	Src   int `json:"src"`
+	Start int `json:"start"`
+	Dst   int `json:"dst"`
+}
+
+// ErrorResponse is the body of every failed request
+type ErrorResponse struct {
+	Error struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// NewHandler serves the REST API:
+//
+//	POST   /games               create a game (CreateRequest)       -> 201 GameResponse
+//	GET    /games/{id}          current state                       -> 200 GameResponse
+//	GET    /games/{id}/moves    legal moves in move notation        -> 200 {"moves": [...]}
+//	POST   /games/{id}/moves    play a move (MoveRequest)           -> 200 GameResponse
+//	POST   /games/{id}/deal     deal a row                          -> 200 GameResponse
+//	POST   /games/{id}/undo     undo the last action                -> 200 GameResponse
+//	DELETE /games/{id}          end the session                     -> 204
+//	GET    /games/{id}/events   Server-Sent Events, one "state" event per change
+//
+// Rule violations answer 422 with the engine's error code. Bodies over maxBodyBytes
+// answer 413.
+func NewHandler(m *Manager) http.Handler {
+	h := &handler{m: m}
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /games", h.create)
+	mux.HandleFunc("GET /games/{id}", h.get)
+	mux.HandleFunc("DELETE /games/{id}", h.delete)
+	mux.HandleFunc("GET /games/{id}/moves", h.legalMoves)
+	mux.HandleFunc("POST /games/{id}/moves", h.action(h.decodeMove))
+	mux.HandleFunc("POST /games/{id}/deal", h.action(noBody((*game.GameState).DealRow)))
+	mux.HandleFunc("POST /games/{id}/undo", h.action(noBody((*game.GameState).Undo)))
+	mux.HandleFunc("GET /games/{id}/events", h.events)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.Body = http.MaxBytesReader(w, r.Body, maxBodyBytes)
+		mux.ServeHTTP(w, r)
+	})
+}
+
+type handler struct {
+	m *Manager
+}
+
+func (h *handler) create(w http.ResponseWriter, r *http.Request) {
+	req := CreateRequest{Suits: int(deck.OneSuit)}
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			badBody(w, fmt.Errorf("invalid JSON body: %w", err))
+			return
+		}
+	}
+	suits := deck.SuitCount(req.Suits)
+	if !suits.Valid() {
+		writeError(w, http.StatusBadRequest, CodeBadRequest, "suits must be 1, 2 or 4")
+		return
+	}
+	seed := deck.RandomSeed()
+	if req.Seed != nil {
+		seed = *req.Seed
+	}
+
+	s, err := h.m.Create(suits, seed)
+	if err != nil {
+		h.fail(w, err)
+		return
+	}
+	logger.Info("Server: created %s (seed=%d, suits=%d)", s.ID, seed, suits)
+	writeJSON(w, http.StatusCreated, GameResponse{ID: s.ID, State: s.View()})
+}
+
+func (h *handler) get(w http.ResponseWriter, r *http.Request) {
+	s, ok := h.session(w, r)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, GameResponse{ID: s.ID, State: s.View()})
+}
+
+func (h *handler) delete(w http.ResponseWriter, r *http.Request) {
+	if err := h.m.Delete(r.PathValue("id")); err != nil {
+		h.fail(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *handler) legalMoves(w http.ResponseWriter, r *http.Request) {
+	s, ok := h.session(w, r)
+	if !ok {
+		return
+	}
+	legal := s.LegalMoves()
+	moves := make([]string, len(legal))
+	for i, m := range legal {
+		moves[i] = m.String()
+	}
+	writeJSON(w, http.StatusOK, map[string][]string{"moves": moves})
+}
+
+// actionFunc reads the request and returns the engine action it asks for
+type actionFunc func(r *http.Request) (func(*game.GameState) error, error)
+
+func noBody(action func(*game.GameState) error) actionFunc {
+	return func(*http.Request) (func(*game.GameState) error, error) { return action, nil }
+}
+
+func (h *handler) decodeMove(r *http.Request) (func(*game.GameState) error, error) {
+	var req MoveRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, fmt.Errorf("invalid JSON body: %w", err)
+	}
+	return func(g *game.GameState) error {
+		return g.MoveSequence(req.Src, req.Start, req.Dst)
+	}, nil
+}
+
+// action wraps an engine action as an endpoint that replies with the new state
+func (h *handler) action(decode actionFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s, ok := h.session(w, r)
+		if !ok {
+			return
+		}
+		act, err := decode(r)
+		if err != nil {
+			badBody(w, err)
+			return
+		}
+		view, events, err := s.Do(act)
+		if err != nil {
+			h.fail(w, err)
+			return
+		}
+		kinds := make([]string, len(events))
+		for i, e := range events {
+			kinds[i] = e.Kind.String()
+		}
+		writeJSON(w, http.StatusOK, GameResponse{ID: s.ID, State: view, Events: kinds})
+	}
+}
+
+// events streams the session's state as Server-Sent Events until it ends or the client leaves
+func (h *handler) events(w http.ResponseWriter, r *http.Request) {
+	s, ok := h.session(w, r)
+	if !ok {
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, http.StatusInternalServerError, CodeInternal, "streaming not supported")
+		return
+	}
+
+	updates, stop := s.Watch()
+	defer stop()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+
+	// current state first so the client never starts blank
+	if err := writeEvent(w, s.View()); err != nil {
+		return
+	}
+	flusher.Flush()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case view, ok := <-updates:
+			if !ok {
+				return // session deleted or expired
+			}
+			if err := writeEvent(w, view); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+func writeEvent(w http.ResponseWriter, view game.GameViewDTO) error {
+	data, err := json.Marshal(view)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "event: state\ndata: %s\n\n", data)
+	return err
+}
+
+// session looks up the {id} in the path, replying 404 when it doesn't exist
+func (h *handler) session(w http.ResponseWriter, r *http.Request) (*Session, bool) {
+	s, err := h.m.Get(r.PathValue("id"))
+	if err != nil {
+		h.fail(w, err)
+		return nil, false
+	}
+	return s, true
+}
+
+// badBody replies to a request body that couldn't be decoded: 413 when it was cut off
+// at maxBodyBytes, 400 otherwise
+func badBody(w http.ResponseWriter, err error) {
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		writeError(w, http.StatusRequestEntityTooLarge, CodeBodyTooLarge, fmt.Sprintf("request body over %d bytes", tooLarge.Limit))
+		return
+	}
+	writeError(w, http.StatusBadRequest, CodeBadRequest, err.Error())
+}
+
+// fail maps an error to its status and code
+func (h *handler) fail(w http.ResponseWriter, err error) {
+	code := game.ErrorCode(err)
+	switch {
+	case errors.Is(err, ErrSessionNotFound):
+		writeError(w, http.StatusNotFound, CodeNotFound, err.Error())
+	case errors.Is(err, ErrTooManySessions):
+		writeError(w, http.StatusServiceUnavailable, CodeTooManySessions, err.Error())
+	case code != "" && code != CodeInternal:
+		writeError(w, http.StatusUnprocessableEntity, code, err.Error())
+	default:
+		logger.Error("Server: %s", err.Error())
+		writeError(w, http.StatusInternalServerError, CodeInternal, err.Error())
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		logger.Error("Server: write response: %s", err.Error())
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, code, msg string) {
+	var body ErrorResponse
+	body.Error.Code = code
+	body.Error.Message = msg
+	writeJSON(w, status, body)
+}
@@ -0,0 +1,155 @@
+package server
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(NewHandler(NewManager(Config{})))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// call sends a request and decodes the JSON reply into out (when non-nil)
+func call(t *testing.T, method, url, body string, out any) int {
+	t.Helper()
+	req, err := http.NewRequest(method, url, strings.NewReader(body))
+	require.NoError(t, err)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	if out != nil {
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(out))
+	}
+	return resp.StatusCode
+}
+
+func createGame(t *testing.T, srv *httptest.Server) GameResponse {
+	t.Helper()
+	var created GameResponse
+	status := call(t, http.MethodPost, srv.URL+"/games", `{"suits": 1, "seed": "3"}`, &created)
+	require.Equal(t, http.StatusCreated, status)
+	return created
+}
+
+func TestAPI_GameLifecycle(t *testing.T) {
+	srv := newTestServer(t)
+	created := createGame(t, srv)
+	assert.Equal(t, uint64(3), created.State.Seed)
+	assert.Equal(t, 1, created.State.SuitCount)
+	base := srv.URL + "/games/" + created.ID
+
+	var got GameResponse
+	assert.Equal(t, http.StatusOK, call(t, http.MethodGet, base, "", &got))
+	assert.Equal(t, created.ID, got.ID)
+
+	var moves map[string][]string
+	assert.Equal(t, http.StatusOK, call(t, http.MethodGet, base+"/moves", "", &moves))
+	assert.Contains(t, moves["moves"], "deal")
+
+	var dealt GameResponse
+	assert.Equal(t, http.StatusOK, call(t, http.MethodPost, base+"/deal", "", &dealt))
+	assert.Equal(t, 40, dealt.State.StockCount)
+	assert.Equal(t, []string{"RowDealt"}, dealt.Events)
+
+	var undone GameResponse
+	assert.Equal(t, http.StatusOK, call(t, http.MethodPost, base+"/undo", "", &undone))
+	assert.Equal(t, 50, undone.State.StockCount)
+
+	assert.Equal(t, http.StatusNoContent, call(t, http.MethodDelete, base, "", nil))
+	var missing ErrorResponse
+	assert.Equal(t, http.StatusNotFound, call(t, http.MethodGet, base, "", &missing))
+	assert.Equal(t, CodeNotFound, missing.Error.Code)
+}
+
+func TestAPI_Errors(t *testing.T) {
+	srv := newTestServer(t)
+	base := srv.URL + "/games/" + createGame(t, srv).ID
+
+	tests := []struct {
+		name   string
+		method string
+		url    string
+		body   string
+		status int
+		code   string
+	}{
+		{"illegal move", http.MethodPost, base + "/moves", `{"src": 0, "start": 5, "dst": 1}`, http.StatusUnprocessableEntity, "destination_not_accepting"},
+		{"face-down card", http.MethodPost, base + "/moves", `{"src": 0, "start": 0, "dst": 1}`, http.StatusUnprocessableEntity, "card_face_down"},
+		{"nothing to undo", http.MethodPost, base + "/undo", "", http.StatusUnprocessableEntity, "no_history"},
+		{"bad move body", http.MethodPost, base + "/moves", `{"src":`, http.StatusBadRequest, CodeBadRequest},
+		{"bad suits", http.MethodPost, srv.URL + "/games", `{"suits": 3}`, http.StatusBadRequest, CodeBadRequest},
+		{"oversized move body", http.MethodPost, base + "/moves", `{"src": "` + strings.Repeat("0", maxBodyBytes) + `"}`, http.StatusRequestEntityTooLarge, CodeBodyTooLarge},
+		{"oversized create body", http.MethodPost, srv.URL + "/games", `{"suits": 1, "pad": "` + strings.Repeat(" ", maxBodyBytes) + `"}`, http.StatusRequestEntityTooLarge, CodeBodyTooLarge},
+		{"unknown game", http.MethodPost, srv.URL + "/games/nope/deal", "", http.StatusNotFound, CodeNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body ErrorResponse
+			assert.Equal(t, tt.status, call(t, tt.method, tt.url, tt.body, &body))
+			assert.Equal(t, tt.code, body.Error.Code)
+			assert.NotEmpty(t, body.Error.Message)
+		})
+	}
+}
+
+func TestAPI_SessionLimit(t *testing.T) {
+	srv := httptest.NewServer(NewHandler(NewManager(Config{MaxSessions: 1})))
+	defer srv.Close()
+
+	createGame(t, srv)
+	var body ErrorResponse
+	assert.Equal(t, http.StatusServiceUnavailable, call(t, http.MethodPost, srv.URL+"/games", "", &body))
+	assert.Equal(t, CodeTooManySessions, body.Error.Code)
+}
+
+func TestAPI_EventStream(t *testing.T) {
+	srv := newTestServer(t)
+	base := srv.URL + "/games/" + createGame(t, srv).ID
+
+	resp, err := http.Get(base + "/events")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+	events := bufio.NewReader(resp.Body)
+
+	first := readEvent(t, events)
+	assert.Contains(t, first, `"stock_count":50`, "the stream opens with the current state")
+
+	call(t, http.MethodPost, base+"/deal", "", nil)
+	assert.Contains(t, readEvent(t, events), `"stock_count":40`)
+
+	// deleting the game ends the stream
+	call(t, http.MethodDelete, base, "", nil)
+	_, err = events.ReadString('\n')
+	assert.ErrorIs(t, err, io.EOF)
+}
+
+// readEvent reads one SSE event and returns its data line
+func readEvent(t *testing.T, r *bufio.Reader) string {
+	t.Helper()
+	var data string
+	for {
+		line, err := r.ReadString('\n')
+		require.NoError(t, err)
+		line = strings.TrimRight(line, "\n")
+		if line == "" {
+			return data
+		}
+		if d, ok := strings.CutPrefix(line, "data: "); ok {
+			data = d
+		} else {
+			assert.Equal(t, "event: state", line)
+		}
+	}
+}
@@ -0,0 +1,271 @@
+// Package server hosts many independent games behind an HTTP/JSON API.
+//
+// GameState is not safe for concurrent use, so every game lives in a Session with
+// its own lock, and a Manager owns the sessions, caps how many exist and expires
+// the ones nobody has touched for a while.
+package server
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/staylor11x/spider-solitaire/internal/deck"
+	"github.com/staylor11x/spider-solitaire/internal/game"
+)
+
+// Defaults used when Config fields are zero
+const (
+	DefaultMaxSessions = 100
+	DefaultIdleTimeout = 30 * time.Minute
+	DefaultWatchGrace  = 2 * time.Hour
+)
+
+var (
+	ErrSessionNotFound = errors.New("no such game session")
+	ErrTooManySessions = errors.New("too many game sessions, try again later")
+)
+
+// Config bounds the sessions a Manager will hold
+type Config struct {
+	MaxSessions int              // live sessions allowed at once
+	IdleTimeout time.Duration    // sessions untouched for this long are expired
+	WatchGrace  time.Duration    // extra idle time allowed while an update stream is open
+	Now         func() time.Time // time source, time.Now when nil (tests inject a fake)
+}
+
+// Manager creates, finds and expires sessions. It is safe for concurrent use.
+type Manager struct {
+	cfg      Config
+	mu       sync.Mutex
+	sessions map[string]*Session
+}
+
+// NewManager creates a manager, filling in defaults for zero Config fields
+func NewManager(cfg Config) *Manager {
+	if cfg.MaxSessions <= 0 {
+		cfg.MaxSessions = DefaultMaxSessions
+	}
+	if cfg.IdleTimeout <= 0 {
+		cfg.IdleTimeout = DefaultIdleTimeout
+	}
+	if cfg.WatchGrace <= 0 {
+		cfg.WatchGrace = DefaultWatchGrace
+	}
+	if cfg.Now == nil {
+		cfg.Now = time.Now
+	}
+	return &Manager{cfg: cfg, sessions: make(map[string]*Session)}
+}
+
+// Create deals a new game in a new session. Idle sessions are expired first to make room.
+func (m *Manager) Create(suits deck.SuitCount, seed uint64) (*Session, error) {
+	g, err := game.DealSeededGame(suits, seed)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if len(m.sessions) >= m.cfg.MaxSessions {
+		m.expireLocked()
+	}
+	if len(m.sessions) >= m.cfg.MaxSessions {
+		return nil, ErrTooManySessions
+	}
+
+	s := &Session{
+		ID:       newSessionID(),
+		game:     g,
+		now:      m.cfg.Now,
+		lastUsed: m.cfg.Now(),
+	}
+	m.sessions[s.ID] = s
+	return s, nil
+}
+
+// Get finds a live session
+func (m *Manager) Get(id string) (*Session, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s, ok := m.sessions[id]
+	if !ok {
+		return nil, ErrSessionNotFound
+	}
+	return s, nil
+}
+
+// Delete ends a session, closing any update streams watching it
+func (m *Manager) Delete(id string) error {
+	m.mu.Lock()
+	s, ok := m.sessions[id]
+	delete(m.sessions, id)
+	m.mu.Unlock()
+
+	if !ok {
+		return ErrSessionNotFound
+	}
+	s.close()
+	return nil
+}
+
+// Len reports how many sessions are live
+func (m *Manager) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.sessions)
+}
+
+// Expire removes every session idle for longer than the timeout, plus the watch grace
+// while it has watchers, and returns how many went. Their streams are closed.
+func (m *Manager) Expire() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.expireLocked()
+}
+
+func (m *Manager) expireLocked() int {
+	now := m.cfg.Now()
+	n := 0
+	for id, s := range m.sessions {
+		if s.expired(now, m.cfg.IdleTimeout, m.cfg.WatchGrace) {
+			delete(m.sessions, id)
+			s.close()
+			n++
+		}
+	}
+	return n
+}
+
+// RunExpiry calls Expire every interval until ctx is done
+func (m *Manager) RunExpiry(ctx context.Context, interval time.Duration) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			m.Expire()
+		}
+	}
+}
+
+// Session is one game and the clients watching it
+type Session struct {
+	ID string
+
+	mu       sync.Mutex // guards everything below
+	game     *game.GameState
+	now      func() time.Time
+	lastUsed time.Time
+	watchers []chan game.GameViewDTO
+	closed   bool
+}
+
+// View returns a snapshot of the game
+func (s *Session) View() game.GameViewDTO {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lastUsed = s.now()
+	return s.game.View()
+}
+
+// LegalMoves lists the moves available in the current position
+func (s *Session) LegalMoves() []game.Move {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lastUsed = s.now()
+	return s.game.LegalMoves()
+}
+
+// Do runs an engine action under the session lock. On success watchers are sent
+// the new view, which is also returned together with the events the action caused.
+func (s *Session) Do(action func(g *game.GameState) error) (game.GameViewDTO, []game.Event, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return game.GameViewDTO{}, nil, ErrSessionNotFound
+	}
+	s.lastUsed = s.now()
+	if err := action(s.game); err != nil {
+		return game.GameViewDTO{}, nil, err
+	}
+
+	view := s.game.View()
+	for _, w := range s.watchers {
+		sendLatest(w, view)
+	}
+	return view, s.game.LastEvents(), nil
+}
+
+// Watch subscribes to views sent after each successful action. The channel holds
+// only the newest view, so a slow reader skips states rather than stalling play.
+// It is closed when the session ends; call stop to unsubscribe earlier. A watched
+// session may sit idle for the manager's WatchGrace on top of its IdleTimeout, so an
+// abandoned stream can't keep it forever, and its idle time starts again when the last
+// watcher stops.
+func (s *Session) Watch() (updates <-chan game.GameViewDTO, stop func()) {
+	ch := make(chan game.GameViewDTO, 1)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		close(ch)
+		return ch, func() {}
+	}
+	s.watchers = append(s.watchers, ch)
+
+	return ch, func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		for i, w := range s.watchers {
+			if w == ch {
+				s.watchers = append(s.watchers[:i], s.watchers[i+1:]...)
+				s.lastUsed = s.now()
+				close(ch)
+				return
+			}
+		}
+	}
+}
+
+// expired reports whether the session has gone longer than idle without an action or a
+// watcher leaving, allowing grace more while anyone is watching
+func (s *Session) expired(now time.Time, idle, grace time.Duration) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.watchers) > 0 {
+		idle += grace
+	}
+	return now.Sub(s.lastUsed) > idle
+}
+
+// close ends the session's streams; later actions report ErrSessionNotFound
+func (s *Session) close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+	for _, w := range s.watchers {
+		close(w)
+	}
+	s.watchers = nil
+}
+
+// sendLatest replaces any unread view in ch with view, never blocking
+func sendLatest(ch chan game.GameViewDTO, view game.GameViewDTO) {
+	select {
+	case <-ch:
+	default:
+	}
+	ch <- view
+}
+
+func newSessionID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:]) // crypto/rand.Read never returns an error
+	return hex.EncodeToString(b[:])
+}
@@ -0,0 +1,170 @@
+package server
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/staylor11x/spider-solitaire/internal/deck"
+	"github.com/staylor11x/spider-solitaire/internal/game"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeNow is a settable time source shared by a manager and its sessions
+type fakeNow struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (f *fakeNow) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.now
+}
+
+func (f *fakeNow) Advance(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.now = f.now.Add(d)
+}
+
+func TestManager_CreateGetDelete(t *testing.T) {
+	m := NewManager(Config{})
+	s, err := m.Create(deck.TwoSuits, 42)
+	require.NoError(t, err)
+	assert.Len(t, s.ID, 32)
+
+	got, err := m.Get(s.ID)
+	require.NoError(t, err)
+	assert.Same(t, s, got)
+	assert.Equal(t, uint64(42), got.View().Seed)
+
+	require.NoError(t, m.Delete(s.ID))
+	_, err = m.Get(s.ID)
+	assert.ErrorIs(t, err, ErrSessionNotFound)
+	assert.ErrorIs(t, m.Delete(s.ID), ErrSessionNotFound)
+}
+
+func TestManager_LimitAndIdleExpiry(t *testing.T) {
+	clock := &fakeNow{now: time.Unix(1000, 0)}
+	m := NewManager(Config{MaxSessions: 2, IdleTimeout: time.Minute, Now: clock.Now})
+
+	a, err := m.Create(deck.OneSuit, 1)
+	require.NoError(t, err)
+	clock.Advance(30 * time.Second)
+	b, err := m.Create(deck.OneSuit, 2)
+	require.NoError(t, err)
+
+	_, err = m.Create(deck.OneSuit, 3)
+	assert.ErrorIs(t, err, ErrTooManySessions, "both sessions are still fresh")
+
+	// a goes idle, b stays busy
+	clock.Advance(45 * time.Second)
+	_, _, err = b.Do((*game.GameState).DealRow)
+	require.NoError(t, err)
+
+	_, err = m.Create(deck.OneSuit, 3)
+	require.NoError(t, err, "the idle session should be expired to make room")
+	_, err = m.Get(a.ID)
+	assert.ErrorIs(t, err, ErrSessionNotFound)
+	_, err = m.Get(b.ID)
+	assert.NoError(t, err)
+
+	clock.Advance(2 * time.Minute)
+	assert.Equal(t, 2, m.Expire())
+	assert.Zero(t, m.Len())
+}
+
+func TestManager_WatchedSessionsStayLive(t *testing.T) {
+	clock := &fakeNow{now: time.Unix(1000, 0)}
+	m := NewManager(Config{IdleTimeout: time.Minute, WatchGrace: time.Hour, Now: clock.Now})
+	s, err := m.Create(deck.OneSuit, 1)
+	require.NoError(t, err)
+
+	_, stop := s.Watch()
+	clock.Advance(5 * time.Minute)
+	assert.Zero(t, m.Expire(), "a session someone is watching gets a grace period")
+
+	stop()
+	clock.Advance(30 * time.Second)
+	assert.Zero(t, m.Expire(), "idle time starts when the last watcher leaves")
+	clock.Advance(time.Minute)
+	assert.Equal(t, 1, m.Expire())
+}
+
+func TestManager_ReapsSessionsWithAbandonedWatchers(t *testing.T) {
+	clock := &fakeNow{now: time.Unix(1000, 0)}
+	m := NewManager(Config{IdleTimeout: time.Minute, WatchGrace: time.Hour, Now: clock.Now})
+	s, err := m.Create(deck.OneSuit, 1)
+	require.NoError(t, err)
+
+	updates, _ := s.Watch() // the client goes away without stopping
+	clock.Advance(time.Hour)
+	assert.Zero(t, m.Expire())
+	clock.Advance(2 * time.Minute)
+	assert.Equal(t, 1, m.Expire(), "the grace is bounded")
+
+	_, open := <-updates
+	assert.False(t, open, "the abandoned stream is closed")
+}
+
+func TestSession_DoAndWatch(t *testing.T) {
+	m := NewManager(Config{})
+	s, err := m.Create(deck.OneSuit, 7)
+	require.NoError(t, err)
+
+	updates, stop := s.Watch()
+
+	_, _, err = s.Do((*game.GameState).Undo)
+	assert.ErrorIs(t, err, game.ErrNoHistory)
+
+	view, events, err := s.Do((*game.GameState).DealRow)
+	require.NoError(t, err)
+	assert.Equal(t, game.EventRowDealt, events[0].Kind)
+	assert.Equal(t, view, <-updates, "watchers get the new view")
+
+	// a slow watcher only sees the newest view
+	_, _, err = s.Do((*game.GameState).DealRow)
+	require.NoError(t, err)
+	view, _, err = s.Do((*game.GameState).DealRow)
+	require.NoError(t, err)
+	assert.Equal(t, view, <-updates)
+
+	stop()
+	_, open := <-updates
+	assert.False(t, open, "stop closes the stream")
+}
+
+func TestSession_DeleteClosesStreams(t *testing.T) {
+	m := NewManager(Config{})
+	s, err := m.Create(deck.OneSuit, 7)
+	require.NoError(t, err)
+	updates, stop := s.Watch()
+	defer stop()
+
+	require.NoError(t, m.Delete(s.ID))
+	_, open := <-updates
+	assert.False(t, open)
+
+	_, _, err = s.Do((*game.GameState).DealRow)
+	assert.ErrorIs(t, err, ErrSessionNotFound, "a deleted session can't be played")
+}
+
+func TestSession_ConcurrentActions(t *testing.T) {
+	m := NewManager(Config{})
+	s, err := m.Create(deck.OneSuit, 7)
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	for range 5 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _, _ = s.Do((*game.GameState).DealRow)
+			_ = s.View()
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 5, s.View().Deals)
+}
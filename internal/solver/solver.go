@@ -0,0 +1,147 @@
+// Package solver searches for a winning line from a full-information spider position.
+//
+// The solver sees everything, including face-down cards and the stock order, so it
+// answers "can this deal be won?" rather than "what would a player do?". Rules are
+// never duplicated here: moves come from GameState.LegalMoves and are played with
+// GameState.Apply on clones, so sequence checks, destination checks and run removal
+// are exactly the engine's.
+package solver
+
+import (
+	"slices"
+	"time"
+
+	"github.com/staylor11x/spider-solitaire/internal/game"
+)
+
+// DefaultMaxNodes bounds the search when Options.MaxNodes is zero
+const DefaultMaxNodes = 200_000
+
+// timeCheckInterval is how many nodes are expanded between clock reads
+const timeCheckInterval = 1024
+
+// Status is the outcome of a search
+type Status int
+
+const (
+	Solved     Status = iota // a winning line was found
+	Unwinnable               // every reachable position was searched without a win
+	GaveUp                   // the node or time budget ran out first
+)
+
+func (s Status) String() string {
+	switch s {
+	case Solved:
+		return "solved"
+	case Unwinnable:
+		return "proved unwinnable"
+	case GaveUp:
+		return "gave up"
+	default:
+		return "unknown"
+	}
+}
+
+// Options bounds a search. Zero values mean the defaults.
+type Options struct {
+	MaxNodes int           // positions to expand before giving up (DefaultMaxNodes when 0)
+	Timeout  time.Duration // wall-clock budget (unlimited when 0)
+}
+
+// Result is what a search found. Moves is only set when Status is Solved.
+type Result struct {
+	Status  Status
+	Moves   []game.Move
+	Nodes   int
+	Elapsed time.Duration
+}
+
+// search holds the state of one Solve call
+type search struct {
+	maxNodes int
+	deadline time.Time
+	nodes    int
+	outOfGas bool
+	seen     map[string]struct{} // transposition table of positions already expanded
+	path     []game.Move
+}
+
+// Solve runs a depth-first search with heuristic move ordering and a transposition
+// table. The given game is never modified.
+func Solve(g *game.GameState, opts Options) Result {
+	start := time.Now()
+
+	s := &search{
+		maxNodes: opts.MaxNodes,
+		seen:     make(map[string]struct{}),
+	}
+	if s.maxNodes <= 0 {
+		s.maxNodes = DefaultMaxNodes
+	}
+	if opts.Timeout > 0 {
+		s.deadline = start.Add(opts.Timeout)
+	}
+
+	solved := s.dfs(g.Clone())
+
+	res := Result{Nodes: s.nodes, Elapsed: time.Since(start)}
+	switch {
+	case solved:
+		res.Status = Solved
+		res.Moves = slices.Clone(s.path)
+	case s.outOfGas:
+		res.Status = GaveUp
+	default:
+		res.Status = Unwinnable
+	}
+	return res
+}
+
+// dfs reports whether g can be won, leaving the winning line in s.path
+func (s *search) dfs(g *game.GameState) bool {
+	if g.Won {
+		return true
+	}
+	if g.Lost {
+		return false
+	}
+
+	key := g.PositionKey()
+	if _, ok := s.seen[key]; ok {
+		return false
+	}
+	if s.exhausted() {
+		return false
+	}
+	s.seen[key] = struct{}{}
+	s.nodes++
+
+	for _, m := range g.OrderedMoves() {
+		child := g.Clone()
+		if err := child.Apply(m); err != nil {
+			continue // LegalMoves and Apply disagreeing would be an engine bug; skip defensively
+		}
+		s.path = append(s.path, m)
+		if s.dfs(child) {
+			return true
+		}
+		s.path = s.path[:len(s.path)-1]
+		if s.outOfGas {
+			return false
+		}
+	}
+	return false
+}
+
+// exhausted reports (and remembers) whether the node or time budget is spent
+func (s *search) exhausted() bool {
+	if s.outOfGas {
+		return true
+	}
+	if s.nodes >= s.maxNodes {
+		s.outOfGas = true
+	} else if !s.deadline.IsZero() && s.nodes%timeCheckInterval == 0 && time.Now().After(s.deadline) {
+		s.outOfGas = true
+	}
+	return s.outOfGas
+}
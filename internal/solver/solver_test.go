@@ -0,0 +1,85 @@
+package solver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/staylor11x/spider-solitaire/internal/deck"
+	"github.com/staylor11x/spider-solitaire/internal/game"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSolve_AlreadyWon(t *testing.T) {
+	res := Solve(&game.GameState{Won: true}, Options{})
+	assert.Equal(t, Solved, res.Status)
+	assert.Empty(t, res.Moves)
+}
+
+func TestSolve_FindsWinningLine(t *testing.T) {
+	// K..3 of spades on pile 0, the Ace on pile 1 and the Two on pile 2
+	g := game.AlmostWon()
+	two, err := g.Tableau.Piles[0].RemoveCardsFrom(game.RunLength - 2)
+	require.NoError(t, err)
+	g.Tableau.Piles[2].AddCards(two)
+	res := Solve(g, Options{})
+	require.Equal(t, Solved, res.Status)
+
+	// the original game is untouched and the returned line actually wins
+	assert.False(t, g.Won)
+	for _, m := range res.Moves {
+		require.NoError(t, g.Apply(m))
+	}
+	assert.True(t, g.Won)
+}
+
+func TestSolve_ProvesUnwinnable(t *testing.T) {
+	// a few loose cards that can shuffle around but never form a run
+	g := &game.GameState{}
+	g.Tableau.Piles[0].AddCard(deck.Card{Suit: deck.Hearts, Rank: deck.Ten}, true)
+	g.Tableau.Piles[1].AddCard(deck.Card{Suit: deck.Spades, Rank: deck.Jack}, true)
+	g.Tableau.Piles[2].AddCard(deck.Card{Suit: deck.Clubs, Rank: deck.Four}, true)
+
+	res := Solve(g, Options{})
+	assert.Equal(t, Unwinnable, res.Status)
+	assert.Empty(t, res.Moves)
+	assert.Positive(t, res.Nodes)
+}
+
+func TestSolve_RespectsNodeBudget(t *testing.T) {
+	g, err := game.DealSeededGame(deck.FourSuits, 1)
+	require.NoError(t, err)
+
+	res := Solve(g, Options{MaxNodes: 50})
+	assert.Equal(t, GaveUp, res.Status)
+	assert.LessOrEqual(t, res.Nodes, 50)
+}
+
+func TestSolve_RespectsTimeBudget(t *testing.T) {
+	g, err := game.DealSeededGame(deck.FourSuits, 1)
+	require.NoError(t, err)
+
+	res := Solve(g, Options{MaxNodes: 10_000_000, Timeout: time.Nanosecond})
+	assert.Equal(t, GaveUp, res.Status)
+}
+
+func TestStatusString(t *testing.T) {
+	assert.Equal(t, "solved", Solved.String())
+	assert.Equal(t, "proved unwinnable", Unwinnable.String())
+	assert.Equal(t, "gave up", GaveUp.String())
+}
+
+func TestSolve_OneSuitDealReplays(t *testing.T) {
+	g, err := game.DealSeededGame(deck.OneSuit, 0)
+	require.NoError(t, err)
+
+	res := Solve(g, Options{})
+	require.Equal(t, Solved, res.Status)
+
+	replay, err := game.DealSeededGame(deck.OneSuit, 0)
+	require.NoError(t, err)
+	for _, m := range res.Moves {
+		require.NoError(t, replay.Apply(m), "move %s", m)
+	}
+	assert.True(t, replay.Won)
+}
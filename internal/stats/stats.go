@@ -0,0 +1,252 @@
+// Package stats keeps the player's log of finished and abandoned games and works out
+// totals from it: games played, win rate, streaks, fastest win and best score.
+// Reading and writing the log file is left to the storage package.
+package stats
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/staylor11x/spider-solitaire/internal/deck"
+	"github.com/staylor11x/spider-solitaire/internal/game"
+	"github.com/staylor11x/spider-solitaire/internal/jsonlog"
+)
+
+// Version is the schema version written by Write
+const Version = 1
+
+// ErrInvalidLog is returned for a statistics file that can't be read
+var ErrInvalidLog = errors.New("invalid statistics file")
+
+// Result is how a logged game ended
+type Result string
+
+const (
+	Won       Result = "won"
+	Lost      Result = "lost"
+	Abandoned Result = "abandoned" // replaced by another game before it was won or lost
+)
+
+// Valid reports whether r is one of the known results
+func (r Result) Valid() bool {
+	return r == Won || r == Lost || r == Abandoned
+}
+
+// Game is one logged game
+type Game struct {
+	Suits    deck.SuitCount
+	Seed     uint64
+	Result   Result
+	Moves    int
+	Score    int
+	Duration time.Duration // time played
+	Ended    time.Time
+}
+
+// FromGame makes the log entry for g as it stands at end: won, lost or abandoned
+func FromGame(g *game.GameState, end time.Time) Game {
+	result := Abandoned
+	switch {
+	case g.Won:
+		result = Won
+	case g.Lost:
+		result = Lost
+	}
+	return Game{
+		Suits:    g.SuitCount,
+		Seed:     g.Seed,
+		Result:   result,
+		Moves:    g.Moves,
+		Score:    g.Score,
+		Duration: g.Elapsed(),
+		Ended:    end,
+	}
+}
+
+// Finished returns the entry a game already won or lost was logged with when it ended,
+// for Replace should the result be undone and the game end again. It reports false for a
+// game still in play.
+func Finished(g *game.GameState) (Game, bool) {
+	if !g.Won && !g.Lost {
+		return Game{}, false
+	}
+	return FromGame(g, time.Time{}), true
+}
+
+// Started reports whether g has had any action, so that replacing it abandons a game
+// rather than just a deal nobody played
+func Started(g *game.GameState) bool {
+	return g.Moves > 0 || g.Deals > 0 || g.Undos > 0
+}
+
+// Log is every logged game, oldest first
+type Log struct {
+	Games []Game
+}
+
+// Add appends a game to the log
+func (l *Log) Add(g Game) {
+	l.Games = append(l.Games, g)
+}
+
+// Replace puts g in place of the latest entry for old, the same game as it was logged
+// before, or adds g when the log has no such entry. A game undone out of a win or loss
+// is logged again this way when it ends, so it counts once. Entries are matched on
+// everything but when they ended, which a resumed game doesn't know, and on whole
+// milliseconds of play, as the file keeps them.
+func (l *Log) Replace(old, g Game) {
+	for i := len(l.Games) - 1; i >= 0; i-- {
+		e := l.Games[i]
+		if e.Suits == old.Suits && e.Seed == old.Seed && e.Result == old.Result && e.Moves == old.Moves &&
+			e.Score == old.Score && e.Duration.Milliseconds() == old.Duration.Milliseconds() {
+			l.Games[i] = g
+			return
+		}
+	}
+	l.Add(g)
+}
+
+// Reset forgets every logged game
+func (l *Log) Reset() {
+	l.Games = nil
+}
+
+// Summary is the totals for a set of logged games
+type Summary struct {
+	Played        int
+	Won           int
+	Lost          int
+	Abandoned     int
+	CurrentStreak int           // wins since the last game that wasn't won
+	BestStreak    int           // longest run of wins in a row
+	FastestWin    time.Duration // zero until a game is won
+	BestScore     int           // highest score of a won game; zero until a game is won
+}
+
+// WinRate is the percentage of played games that were won, zero when none were
+func (s Summary) WinRate() float64 {
+	if s.Played == 0 {
+		return 0
+	}
+	return 100 * float64(s.Won) / float64(s.Played)
+}
+
+// Summary totals the games played at suits, or every game when suits is zero.
+// Abandoned games count as played and break a winning streak. Like the fastest win, the
+// best score only comes from games that were won.
+func (l *Log) Summary(suits deck.SuitCount) Summary {
+	var s Summary
+	for _, g := range l.Games {
+		if suits != 0 && g.Suits != suits {
+			continue
+		}
+		s.Played++
+		switch g.Result {
+		case Won:
+			if s.Won == 0 || g.Score > s.BestScore {
+				s.BestScore = g.Score
+			}
+			s.Won++
+			s.CurrentStreak++
+			s.BestStreak = max(s.BestStreak, s.CurrentStreak)
+			if s.FastestWin == 0 || g.Duration < s.FastestWin {
+				s.FastestWin = g.Duration
+			}
+			continue
+		case Lost:
+			s.Lost++
+		default:
+			s.Abandoned++
+		}
+		s.CurrentStreak = 0
+	}
+	return s
+}
+
+// savedGame is the on-disk shape of a Game, kept separate so the types can change without
+// silently changing the file format
+type savedGame struct {
+	Suits      int       `json:"suits"`
+	Seed       uint64    `json:"seed"`
+	Result     string    `json:"result"`
+	Moves      int       `json:"moves"`
+	Score      int       `json:"score"`
+	DurationMS int64     `json:"duration_ms"`
+	Ended      time.Time `json:"ended"`
+}
+
+// Write encodes the log as versioned JSON
+func (l *Log) Write(w io.Writer) error {
+	games := make([]savedGame, len(l.Games))
+	for i, g := range l.Games {
+		games[i] = savedGame{
+			Suits:      int(g.Suits),
+			Seed:       g.Seed,
+			Result:     string(g.Result),
+			Moves:      g.Moves,
+			Score:      g.Score,
+			DurationMS: g.Duration.Milliseconds(),
+			Ended:      g.Ended,
+		}
+	}
+	return jsonlog.Write(w, Version, "games", games)
+}
+
+// Read decodes a log written by Write
+func Read(r io.Reader) (*Log, error) {
+	games, err := jsonlog.Read[savedGame](r, Version, "games")
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidLog, err)
+	}
+	l := &Log{}
+	for _, sg := range games {
+		g := Game{
+			Suits:    deck.SuitCount(sg.Suits),
+			Seed:     sg.Seed,
+			Result:   Result(sg.Result),
+			Moves:    sg.Moves,
+			Score:    sg.Score,
+			Duration: time.Duration(sg.DurationMS) * time.Millisecond,
+			Ended:    sg.Ended,
+		}
+		if !g.Suits.Valid() {
+			return nil, fmt.Errorf("%w: bad suit count %d", ErrInvalidLog, sg.Suits)
+		}
+		if !g.Result.Valid() {
+			return nil, fmt.Errorf("%w: bad result %q", ErrInvalidLog, sg.Result)
+		}
+		l.Add(g)
+	}
+	return l, nil
+}
+
+// csvHeader names the columns written by WriteCSV
+var csvHeader = []string{"ended", "suits", "seed", "result", "moves", "score", "seconds"}
+
+// WriteCSV writes one row per logged game, oldest first, under a header row
+func (l *Log) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return err
+	}
+	for _, g := range l.Games {
+		row := []string{
+			g.Ended.Format(time.RFC3339),
+			strconv.Itoa(int(g.Suits)),
+			strconv.FormatUint(g.Seed, 10),
+			string(g.Result),
+			strconv.Itoa(g.Moves),
+			strconv.Itoa(g.Score),
+			strconv.FormatFloat(g.Duration.Seconds(), 'f', 0, 64),
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,130 @@
+package stats
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/staylor11x/spider-solitaire/internal/deck"
+	"github.com/staylor11x/spider-solitaire/internal/game"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var day = time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+func entry(suits deck.SuitCount, result Result, score int, d time.Duration) Game {
+	return Game{Suits: suits, Seed: 1, Result: result, Moves: 10, Score: score, Duration: d, Ended: day}
+}
+
+func TestSummary_Totals(t *testing.T) {
+	l := &Log{}
+	l.Add(entry(deck.OneSuit, Won, 700, 9*time.Minute))
+	l.Add(entry(deck.OneSuit, Won, 650, 7*time.Minute))
+	l.Add(entry(deck.OneSuit, Won, 600, 8*time.Minute))
+	l.Add(entry(deck.OneSuit, Abandoned, 980, time.Minute))
+	l.Add(entry(deck.OneSuit, Won, 640, 6*time.Minute))
+	l.Add(entry(deck.TwoSuits, Lost, 300, 20*time.Minute))
+
+	s := l.Summary(deck.OneSuit)
+	assert.Equal(t, 5, s.Played)
+	assert.Equal(t, 4, s.Won)
+	assert.Equal(t, 1, s.Abandoned)
+	assert.Equal(t, 80.0, s.WinRate())
+	assert.Equal(t, 1, s.CurrentStreak)
+	assert.Equal(t, 3, s.BestStreak, "the abandoned game broke the streak")
+	assert.Equal(t, 6*time.Minute, s.FastestWin)
+	assert.Equal(t, 700, s.BestScore, "the abandoned game's higher score doesn't count")
+
+	all := l.Summary(0)
+	assert.Equal(t, 6, all.Played)
+	assert.Equal(t, 1, all.Lost)
+	assert.Equal(t, 0, all.CurrentStreak)
+}
+
+func TestSummary_Empty(t *testing.T) {
+	l := &Log{}
+	l.Add(entry(deck.FourSuits, Lost, -20, time.Minute))
+
+	assert.Equal(t, Summary{}, l.Summary(deck.OneSuit))
+	assert.Zero(t, l.Summary(deck.OneSuit).WinRate())
+
+	s := l.Summary(deck.FourSuits)
+	assert.Zero(t, s.BestScore, "a lost game has no best score")
+	assert.Zero(t, s.FastestWin)
+
+	l.Add(entry(deck.FourSuits, Won, -40, time.Hour))
+	assert.Equal(t, -40, l.Summary(deck.FourSuits).BestScore, "a negative winning score is still the best so far")
+}
+
+func TestReplace(t *testing.T) {
+	l := &Log{}
+	lost := entry(deck.OneSuit, Lost, 450, 90*time.Second)
+	l.Add(entry(deck.OneSuit, Won, 700, time.Minute))
+	l.Add(lost)
+
+	won := entry(deck.OneSuit, Won, 620, 2*time.Minute)
+	resumed := lost
+	resumed.Duration += 300 * time.Microsecond // elapsed time as kept in memory, finer than the file
+	resumed.Ended = time.Time{}
+	l.Replace(resumed, won)
+	require.Len(t, l.Games, 2)
+	assert.Equal(t, won, l.Games[1])
+
+	l.Replace(lost, won)
+	assert.Len(t, l.Games, 3, "a game that isn't in the log is added")
+}
+
+func TestFromGame(t *testing.T) {
+	g, err := game.DealSeededGame(deck.TwoSuits, 9)
+	require.NoError(t, err)
+	assert.False(t, Started(g))
+	require.NoError(t, g.DealRow())
+	assert.True(t, Started(g))
+	g.Pause() // keeps Elapsed still
+
+	e := FromGame(g, day)
+	assert.Equal(t, Game{Suits: deck.TwoSuits, Seed: 9, Result: Abandoned, Score: g.Score, Duration: g.Elapsed(), Ended: day}, e)
+
+	g.Won = true
+	assert.Equal(t, Won, FromGame(g, day).Result)
+}
+
+func TestWriteRead_RoundTrip(t *testing.T) {
+	l := &Log{}
+	l.Add(entry(deck.OneSuit, Won, 700, 90*time.Second))
+	l.Add(entry(deck.FourSuits, Abandoned, 420, 1500*time.Millisecond))
+
+	var buf bytes.Buffer
+	require.NoError(t, l.Write(&buf))
+	got, err := Read(&buf)
+	require.NoError(t, err)
+	assert.Equal(t, l, got)
+}
+
+func TestRead_RejectsBadInput(t *testing.T) {
+	for name, input := range map[string]string{
+		"not json":    "stats",
+		"newer":       `{"version": 2}`,
+		"bad suits":   `{"version": 1, "games": [{"suits": 3, "result": "won"}]}`,
+		"bad result":  `{"version": 1, "games": [{"suits": 1, "result": "draw"}]}`,
+		"no version":  `{"games": []}`,
+		"wrong shape": `{"version": 1, "games": {}}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := Read(strings.NewReader(input))
+			assert.ErrorIs(t, err, ErrInvalidLog)
+		})
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	l := &Log{}
+	l.Add(entry(deck.TwoSuits, Lost, 350, 125*time.Second))
+
+	var buf bytes.Buffer
+	require.NoError(t, l.WriteCSV(&buf))
+	assert.Equal(t, "ended,suits,seed,result,moves,score,seconds\n"+
+		"2026-10-16T12:00:00Z,2,1,lost,10,350,125\n", buf.String())
+}
@@ -0,0 +1,323 @@
+// Package storage locates and writes the game's local data files
+// (save games, and anything else that should survive a restart).
+package storage
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/staylor11x/spider-solitaire/internal/daily"
+	"github.com/staylor11x/spider-solitaire/internal/game"
+	"github.com/staylor11x/spider-solitaire/internal/record"
+	"github.com/staylor11x/spider-solitaire/internal/stats"
+)
+
+const (
+	appDirName       = "spider-solitaire"
+	saveFileName     = "save.json"
+	settingsFileName = "settings.json"
+	statsFileName    = "stats.json"
+	statsCSVFileName = "stats.csv"
+	dailyFileName    = "daily.json"
+)
+
+// Dir returns the per-user data directory, creating it if needed.
+// SPIDER_DATA_DIR overrides the location (handy for tests and portable installs).
+func Dir() (string, error) {
+	dir := os.Getenv("SPIDER_DATA_DIR")
+	if dir == "" {
+		base, err := os.UserConfigDir()
+		if err != nil {
+			return "", fmt.Errorf("locate config dir: %w", err)
+		}
+		dir = filepath.Join(base, appDirName)
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("create data dir: %w", err)
+	}
+	return dir, nil
+}
+
+// SavePath returns the default location of the resumable save game
+func SavePath() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, saveFileName), nil
+}
+
+// SettingsPath returns the location of the player's preferences
+func SettingsPath() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, settingsFileName), nil
+}
+
+// StatsPath returns the location of the player's game statistics
+func StatsPath() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, statsFileName), nil
+}
+
+// StatsCSVPath returns where the statistics are exported as CSV
+func StatsCSVPath() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, statsCSVFileName), nil
+}
+
+// DailyPath returns the location of the player's daily challenge results
+func DailyPath() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, dailyFileName), nil
+}
+
+// RecordPath returns where an exported game record for the given deal is written
+func RecordPath(seed uint64) (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, fmt.Sprintf("record-%d.txt", seed)), nil
+}
+
+// WriteFileAtomic writes to a temp file next to path and renames it into place,
+// so a crash mid-write never leaves a truncated file behind.
+func WriteFileAtomic(path string, write func(io.Writer) error) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) // no-op once renamed
+
+	if err := write(tmp); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// SaveGame writes g to path in the versioned save format
+func SaveGame(path string, g *game.GameState) error {
+	if err := WriteFileAtomic(path, g.Save); err != nil {
+		return fmt.Errorf("save game: %w", err)
+	}
+	return nil
+}
+
+// LoadGame reads a game from path. A missing file is reported as os.ErrNotExist.
+func LoadGame(path string) (*game.GameState, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	g, err := game.Load(f)
+	if err != nil {
+		return nil, fmt.Errorf("load game %s: %w", path, err)
+	}
+	return g, nil
+}
+
+// ErrGameOver is returned by ResumeGame for a save whose game was already won or lost
+var ErrGameOver = errors.New("saved game is already over")
+
+// ResumeGame loads the game at path to carry on playing it. A save of a game that is
+// already won or lost can't be resumed: it is deleted and ErrGameOver returned.
+func ResumeGame(path string) (*game.GameState, error) {
+	g, err := LoadGame(path)
+	if err != nil {
+		return nil, err
+	}
+	if g.Won || g.Lost {
+		if err := RemoveSave(path); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("load game %s: %w", path, ErrGameOver)
+	}
+	return g, nil
+}
+
+// RemoveSave deletes the save game at path. A missing file is not an error.
+func RemoveSave(path string) error {
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("remove save: %w", err)
+	}
+	return nil
+}
+
+// SaveRecord writes a game record to path in the text notation
+func SaveRecord(path string, rec *record.Record) error {
+	if err := WriteFileAtomic(path, rec.Write); err != nil {
+		return fmt.Errorf("save record: %w", err)
+	}
+	return nil
+}
+
+// LoadRecord reads and parses the game record at path
+func LoadRecord(path string) (*record.Record, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	rec, err := record.Parse(f)
+	if err != nil {
+		return nil, fmt.Errorf("load record %s: %w", path, err)
+	}
+	return rec, nil
+}
+
+// HasSave reports whether a save game exists at path
+func HasSave(path string) bool {
+	_, err := os.Stat(path)
+	return !errors.Is(err, os.ErrNotExist)
+}
+
+// Settings are the player's preferences. Zero fields mean "use the default".
+type Settings struct {
+	CardBack string `json:"card_back,omitempty"` // card back colour, e.g. "blue"
+}
+
+// LoadSettings reads the preferences at path. A missing file is not an error:
+// it yields zero Settings, as on first launch.
+func LoadSettings(path string) (Settings, error) {
+	var s Settings
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return s, err
+	}
+	if err := json.Unmarshal(data, &s); err != nil {
+		return Settings{}, fmt.Errorf("load settings %s: %w", path, err)
+	}
+	return s, nil
+}
+
+// SaveSettings writes the preferences to path
+func SaveSettings(path string, s Settings) error {
+	err := WriteFileAtomic(path, func(w io.Writer) error {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(s)
+	})
+	if err != nil {
+		return fmt.Errorf("save settings: %w", err)
+	}
+	return nil
+}
+
+// LoadStats reads the statistics at path. A missing file is not an error:
+// it yields an empty log, as before the first game is finished.
+func LoadStats(path string) (*stats.Log, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return &stats.Log{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	l, err := stats.Read(f)
+	if err != nil {
+		return nil, fmt.Errorf("load stats %s: %w", path, err)
+	}
+	return l, nil
+}
+
+// SaveStats writes the statistics to path
+func SaveStats(path string, l *stats.Log) error {
+	if err := WriteFileAtomic(path, l.Write); err != nil {
+		return fmt.Errorf("save stats: %w", err)
+	}
+	return nil
+}
+
+// ExportStatsCSV writes the statistics to path as CSV
+func ExportStatsCSV(path string, l *stats.Log) error {
+	if err := WriteFileAtomic(path, l.WriteCSV); err != nil {
+		return fmt.Errorf("export stats: %w", err)
+	}
+	return nil
+}
+
+// AddStats logs one game in the statistics at path
+func AddStats(path string, g stats.Game) error {
+	l, err := LoadStats(path)
+	if err != nil {
+		return err
+	}
+	l.Add(g)
+	return SaveStats(path, l)
+}
+
+// ReplaceStats logs g in the statistics at path in place of old, an earlier entry for the
+// same game, or adds it when old isn't there
+func ReplaceStats(path string, old, g stats.Game) error {
+	l, err := LoadStats(path)
+	if err != nil {
+		return err
+	}
+	l.Replace(old, g)
+	return SaveStats(path, l)
+}
+
+// LoadDaily reads the daily challenge results at path. A missing file is not an error:
+// it yields an empty log, as before the first challenge is played.
+func LoadDaily(path string) (*daily.Log, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return &daily.Log{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	l, err := daily.Read(f)
+	if err != nil {
+		return nil, fmt.Errorf("load daily %s: %w", path, err)
+	}
+	return l, nil
+}
+
+// SaveDaily writes the daily challenge results to path
+func SaveDaily(path string, l *daily.Log) error {
+	if err := WriteFileAtomic(path, l.Write); err != nil {
+		return fmt.Errorf("save daily: %w", err)
+	}
+	return nil
+}
+
+// AddDaily records one day's challenge in the results at path
+func AddDaily(path string, e daily.Entry) error {
+	l, err := LoadDaily(path)
+	if err != nil {
+		return err
+	}
+	l.Record(e)
+	return SaveDaily(path, l)
+}
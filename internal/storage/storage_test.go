@@ -0,0 +1,169 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/staylor11x/spider-solitaire/internal/daily"
+	"github.com/staylor11x/spider-solitaire/internal/deck"
+	"github.com/staylor11x/spider-solitaire/internal/game"
+	"github.com/staylor11x/spider-solitaire/internal/record"
+	"github.com/staylor11x/spider-solitaire/internal/stats"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// frozenClock never advances, so views taken at different moments compare equal
+type frozenClock struct{}
+
+func (frozenClock) Now() time.Time { return time.Unix(0, 0) }
+
+func TestDir_HonorsOverride(t *testing.T) {
+	want := filepath.Join(t.TempDir(), "data")
+	t.Setenv("SPIDER_DATA_DIR", want)
+
+	dir, err := Dir()
+	require.NoError(t, err)
+	assert.Equal(t, want, dir)
+
+	info, err := os.Stat(dir)
+	require.NoError(t, err)
+	assert.True(t, info.IsDir(), "data dir should be created")
+}
+
+func TestSaveGame_LoadGame_RoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "save.json")
+
+	g, err := game.DealSeededGame(deck.FourSuits, 5)
+	require.NoError(t, err)
+	g.SetClock(frozenClock{})
+	require.NoError(t, g.DealRow())
+
+	assert.False(t, HasSave(path))
+	require.NoError(t, SaveGame(path, g))
+	assert.True(t, HasSave(path))
+
+	loaded, err := LoadGame(path)
+	require.NoError(t, err)
+	assert.Equal(t, g.View(), loaded.View())
+
+	// no temp files left behind
+	entries, err := os.ReadDir(filepath.Dir(path))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
+
+func TestLoadGame_Missing(t *testing.T) {
+	_, err := LoadGame(filepath.Join(t.TempDir(), "nope.json"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestResumeGame(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "save.json")
+	g, err := game.DealSeededGame(deck.OneSuit, 5)
+	require.NoError(t, err)
+	require.NoError(t, SaveGame(path, g))
+
+	resumed, err := ResumeGame(path)
+	require.NoError(t, err)
+	assert.Equal(t, g.View().Tableau, resumed.View().Tableau)
+
+	g.Lost = true
+	require.NoError(t, SaveGame(path, g))
+	_, err = ResumeGame(path)
+	assert.ErrorIs(t, err, ErrGameOver)
+	assert.False(t, HasSave(path), "a finished game's save is removed")
+
+	require.NoError(t, RemoveSave(path), "nothing left to remove")
+}
+
+func TestSaveRecord_LoadRecord_RoundTrip(t *testing.T) {
+	t.Setenv("SPIDER_DATA_DIR", t.TempDir())
+	path, err := RecordPath(77)
+	require.NoError(t, err)
+	assert.Equal(t, "record-77.txt", filepath.Base(path))
+
+	rec := &record.Record{
+		Suits: deck.OneSuit,
+		Seed:  77,
+		Steps: []record.Step{{Move: game.DealMove()}, {Undo: true}},
+	}
+	require.NoError(t, SaveRecord(path, rec))
+
+	loaded, err := LoadRecord(path)
+	require.NoError(t, err)
+	assert.Equal(t, rec.String(), loaded.String())
+}
+
+func TestSettings_RoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "settings.json")
+
+	s, err := LoadSettings(path)
+	require.NoError(t, err, "a missing settings file means defaults")
+	assert.Equal(t, Settings{}, s)
+
+	require.NoError(t, SaveSettings(path, Settings{CardBack: "blue"}))
+	s, err = LoadSettings(path)
+	require.NoError(t, err)
+	assert.Equal(t, "blue", s.CardBack)
+
+	require.NoError(t, os.WriteFile(path, []byte("{"), 0o644))
+	_, err = LoadSettings(path)
+	assert.Error(t, err)
+}
+
+func TestStats_AddAndExport(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "stats.json")
+
+	l, err := LoadStats(path)
+	require.NoError(t, err, "a missing stats file means no games yet")
+	assert.Empty(t, l.Games)
+
+	won := stats.Game{Suits: deck.OneSuit, Seed: 4, Result: stats.Won, Score: 720, Ended: time.Unix(0, 0).UTC()}
+	require.NoError(t, AddStats(path, won))
+	require.NoError(t, AddStats(path, won))
+	l, err = LoadStats(path)
+	require.NoError(t, err)
+	assert.Equal(t, []stats.Game{won, won}, l.Games)
+
+	lost := won
+	lost.Result, lost.Score = stats.Lost, 400
+	require.NoError(t, ReplaceStats(path, won, lost))
+	l, err = LoadStats(path)
+	require.NoError(t, err)
+	assert.Equal(t, []stats.Game{won, lost}, l.Games, "the latest entry is replaced")
+
+	csvPath := filepath.Join(dir, "stats.csv")
+	require.NoError(t, ExportStatsCSV(csvPath, l))
+	data, err := os.ReadFile(csvPath)
+	require.NoError(t, err)
+	assert.Equal(t, 3, strings.Count(string(data), "\n"), "header and one row per game")
+
+	require.NoError(t, os.WriteFile(path, []byte("{"), 0o644))
+	_, err = LoadStats(path)
+	assert.ErrorIs(t, err, stats.ErrInvalidLog)
+}
+
+func TestDaily_AddKeepsBest(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "daily.json")
+
+	l, err := LoadDaily(path)
+	require.NoError(t, err, "a missing daily file means no challenges yet")
+	assert.Empty(t, l.Entries)
+
+	lost := daily.Entry{Date: "2026-10-16", Suits: deck.TwoSuits, Result: stats.Lost, Score: 380}
+	won := daily.Entry{Date: "2026-10-16", Suits: deck.TwoSuits, Result: stats.Won, Score: 610}
+	require.NoError(t, AddDaily(path, lost))
+	require.NoError(t, AddDaily(path, won))
+	l, err = LoadDaily(path)
+	require.NoError(t, err)
+	assert.Equal(t, []daily.Entry{won}, l.Entries)
+
+	require.NoError(t, os.WriteFile(path, []byte("{"), 0o644))
+	_, err = LoadDaily(path)
+	assert.ErrorIs(t, err, daily.ErrInvalidLog)
+}
@@ -0,0 +1,315 @@
+package ui
+
+import (
+	"math"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/staylor11x/spider-solitaire/internal/deck"
+	"github.com/staylor11x/spider-solitaire/internal/game"
+)
+
+// The animation layer is purely visual. The engine has already applied an action when
+// it is animated: the animator replays the action's events as card sprites travelling
+// from where they were to where the new view has them, and the tableau hides those
+// cards until their sprites land. Time is measured with a clock, not in frames, so
+// animations take as long at any frame rate.
+
+// vec is a point in logical screen coordinates
+type vec struct{ X, Y float64 }
+
+// leg is one stretch of a sprite's journey, timed from the start of the animation
+type leg struct {
+	from, to   vec
+	start, end time.Duration
+	flip       bool // the card turns face up half way through
+}
+
+// sprite is one card in motion. Before its first leg it waits at the first leg's start
+// point; between legs it rests where the previous one ended; after the last it is gone
+// and the card is drawn by the view again.
+type sprite struct {
+	card   game.CardDTO // as shown once any flip is done
+	faceUp bool         // whether the card starts face up
+	legs   []leg
+}
+
+func (s *sprite) end() time.Duration {
+	return s.legs[len(s.legs)-1].end
+}
+
+// at returns where the sprite is at time t, its horizontal scale (for flips) and which side shows
+func (s *sprite) at(t time.Duration) (pos vec, scaleX float64, faceUp bool) {
+	faceUp = s.faceUp
+	pos = s.legs[0].from
+	for _, l := range s.legs {
+		switch {
+		case t >= l.end:
+			pos = l.to
+			if l.flip {
+				faceUp = true
+			}
+		case t > l.start:
+			f := float64(t-l.start) / float64(l.end-l.start)
+			if l.flip {
+				// shrink to an edge, then grow back showing the face
+				return l.from.lerp(l.to, f), math.Abs(1 - 2*f), f >= 0.5
+			}
+			return l.from.lerp(l.to, easeOutCubic(f)), 1, faceUp
+		default:
+			return pos, 1, faceUp
+		}
+	}
+	return pos, 1, faceUp
+}
+
+func (a vec) lerp(b vec, f float64) vec {
+	return vec{a.X + (b.X-a.X)*f, a.Y + (b.Y-a.Y)*f}
+}
+
+func easeOutCubic(f float64) float64 {
+	return 1 - math.Pow(1-f, 3)
+}
+
+// animation is a planned set of sprites for one action
+type animation struct {
+	sprites []*sprite
+	// slots mirrors the new view's tableau: the sprite that lands on each card, or nil
+	slots [][]*sprite
+	moved []*sprite // the cards of the action's sequence move, if it had one
+	runs  int       // completed runs flying to the foundation
+	end   time.Duration
+}
+
+// planned is one card of a pile while an animation is being planned
+type planned struct {
+	card game.CardDTO
+	sp   *sprite
+}
+
+// planAnimation turns the events of one action into sprites. before and after are the
+// views either side of the action. Undos and anything without events are not animated.
+func planAnimation(theme *Theme, before, after game.GameViewDTO, events []game.Event) animation {
+	if len(events) == 0 || len(before.Tableau) != len(after.Tableau) {
+		return animation{}
+	}
+	piles := make([][]planned, len(before.Tableau))
+	for i, p := range before.Tableau {
+		for _, c := range p.Cards {
+			piles[i] = append(piles[i], planned{card: c})
+		}
+	}
+
+	var (
+		an        animation
+		t         time.Duration
+		completed = before.CompletedCount
+		timing    = theme.Timing
+	)
+	// move sends a card on a leg, starting a sprite from its current place if it has none
+	move := func(c *planned, from, to vec, start, dur time.Duration, flip bool) {
+		if c.sp == nil {
+			c.sp = &sprite{faceUp: c.card.FaceUp && !flip}
+			an.sprites = append(an.sprites, c.sp)
+		} else {
+			from = c.sp.legs[len(c.sp.legs)-1].to
+		}
+		c.sp.card = c.card
+		c.sp.legs = append(c.sp.legs, leg{from: from, to: to, start: start, end: start + dur, flip: flip})
+		an.end = max(an.end, start+dur)
+	}
+
+	for i, e := range events {
+		switch e.Kind {
+		case game.EventUndoApplied:
+			return animation{}
+
+		case game.EventMoveApplied:
+			src, dst := e.Move.Src, e.Move.Dst
+			if src < 0 || src >= len(piles) || dst < 0 || dst >= len(piles) || e.Count > len(piles[src]) {
+				return animation{}
+			}
+			n := len(piles[src])
+			moving := piles[src][n-e.Count:]
+			from := make([]vec, len(moving))
+			for k := range moving {
+				from[k] = pilePos(theme, src, n-e.Count+k, n)
+			}
+			piles[src] = piles[src][:n-e.Count]
+			piles[dst] = append(piles[dst], moving...)
+			m := len(piles[dst])
+			for k := range moving {
+				c := &piles[dst][m-len(moving)+k]
+				move(c, from[k], pilePos(theme, dst, m-len(moving)+k, m), t, timing.Move, false)
+				an.moved = append(an.moved, c.sp)
+			}
+			t += timing.Move
+
+		case game.EventRowDealt:
+			stock := vec{float64(theme.Layout.StockX), float64(theme.Layout.StockY)}
+			for p := range piles {
+				c := planned{card: dealtCard(after, events[i+1:], p, len(piles[p]))}
+				piles[p] = append(piles[p], c)
+				n := len(piles[p])
+				move(&piles[p][n-1], stock, pilePos(theme, p, n-1, n), t+time.Duration(p)*timing.DealStagger, timing.Deal, false)
+			}
+			t += time.Duration(len(piles)-1)*timing.DealStagger + timing.Deal
+
+		case game.EventCardRevealed:
+			p := e.Pile
+			if p < 0 || p >= len(piles) || len(piles[p]) == 0 {
+				return animation{}
+			}
+			n := len(piles[p])
+			c := &piles[p][n-1]
+			c.card = game.CardDTO{Rank: game.RankDTO(e.Card.Rank), Suit: game.SuitDTO(e.Card.Suit), FaceUp: true}
+			pos := pilePos(theme, p, n-1, n)
+			move(c, pos, pos, t, timing.Flip, true)
+			t += timing.Flip
+
+		case game.EventRunCompleted:
+			p := e.Pile
+			if p < 0 || p >= len(piles) || len(piles[p]) < game.RunLength {
+				return animation{}
+			}
+			n := len(piles[p])
+			run := piles[p][n-game.RunLength:]
+			fx, fy := foundationSlot(theme, completed)
+			to := vec{float64(fx), float64(fy)}
+			// the Ace leaves first, the King (which ends on top) last
+			for k := len(run) - 1; k >= 0; k-- {
+				start := t + time.Duration(len(run)-1-k)*timing.RunStagger
+				move(&run[k], pilePos(theme, p, n-game.RunLength+k, n), to, start, timing.Run, false)
+			}
+			piles[p] = piles[p][:n-game.RunLength]
+			completed++
+			an.runs++
+			t += time.Duration(game.RunLength-1)*timing.RunStagger + timing.Run
+		}
+	}
+
+	if an.end <= 0 {
+		return animation{} // zero timings: nothing to show
+	}
+	an.slots = make([][]*sprite, len(piles))
+	for p, cards := range piles {
+		an.slots[p] = make([]*sprite, len(cards))
+		for k, c := range cards {
+			an.slots[p][k] = c.sp
+		}
+	}
+	return an
+}
+
+// dealtCard works out which card a deal put on pile p at index idx: normally it is still
+// there in the new view, but if a run was completed on the pile later in the same action
+// it was that run's Ace
+func dealtCard(after game.GameViewDTO, later []game.Event, p, idx int) game.CardDTO {
+	for _, e := range later {
+		if e.Kind == game.EventRunCompleted && e.Pile == p {
+			return game.CardDTO{Rank: game.RankDTO(deck.Ace), Suit: game.SuitDTO(e.Card.Suit), FaceUp: true}
+		}
+	}
+	if cards := after.Tableau[p].Cards; idx < len(cards) {
+		return cards[idx]
+	}
+	return game.CardDTO{FaceUp: true}
+}
+
+// pilePos is the top-left of card idx in pile p when the pile holds n cards
+func pilePos(theme *Theme, p, idx, n int) vec {
+	x := theme.Layout.TableauStartX + p*theme.Layout.PileSpacing
+	return vec{float64(x), float64(computeTableauPileLayout(theme, n).CardY[idx])}
+}
+
+// animator plays animations against a clock
+type animator struct {
+	now     func() time.Time // time source; time.Now when nil
+	started time.Time
+	current animation
+}
+
+func (a *animator) clock() time.Time {
+	if a.now == nil {
+		return time.Now()
+	}
+	return a.now()
+}
+
+// play starts an animation, replacing whatever was playing
+func (a *animator) play(an animation) {
+	a.current = an
+	a.started = a.clock()
+}
+
+func (a *animator) elapsed() time.Duration {
+	return a.clock().Sub(a.started)
+}
+
+// active reports whether an animation is still playing
+func (a *animator) active() bool {
+	return len(a.current.sprites) > 0 && a.elapsed() < a.current.end
+}
+
+// dropped starts the moved cards from where they were dropped, dx, dy from their old
+// place, rather than from their old pile
+func (a *animator) dropped(dx, dy float64) {
+	for _, s := range a.current.moved {
+		s.legs[0].from.X += dx
+		s.legs[0].from.Y += dy
+	}
+}
+
+// runsInFlight is how many completed runs have not reached the foundation yet
+func (a *animator) runsInFlight() int {
+	if !a.active() {
+		return 0
+	}
+	return a.current.runs
+}
+
+// finish jumps to the end of the current animation
+func (a *animator) finish() {
+	a.current = animation{}
+}
+
+// hiddenFrom returns, per pile, the index from which cards are still in flight and must
+// not be drawn by the tableau. In-flight cards are always at the top of their pile.
+func (a *animator) hiddenFrom(view game.GameViewDTO) []int {
+	if !a.active() || len(a.current.slots) != len(view.Tableau) {
+		return nil
+	}
+	t := a.elapsed()
+	from := make([]int, len(view.Tableau))
+	for p, pile := range view.Tableau {
+		from[p] = len(pile.Cards)
+		slots := a.current.slots[p]
+		for k := range min(len(slots), len(pile.Cards)) {
+			if slots[k] != nil && t < slots[k].end() {
+				from[p] = k
+				break
+			}
+		}
+	}
+	return from
+}
+
+// draw renders the sprites in flight. Sprites that haven't set off yet are drawn first so
+// moving cards pass over them.
+func (a *animator) draw(screen *ebiten.Image, atlas *CardAtlas, theme *Theme) {
+	if !a.active() {
+		return
+	}
+	t := a.elapsed()
+	for _, moving := range []bool{false, true} {
+		for _, s := range a.current.sprites {
+			if t >= s.end() || (t > s.legs[0].start) != moving {
+				continue
+			}
+			pos, scaleX, faceUp := s.at(t)
+			card := s.card
+			card.FaceUp = faceUp
+			drawCardScaled(screen, card, pos.X, pos.Y, scaleX, atlas, theme)
+		}
+	}
+}
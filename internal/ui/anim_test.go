@@ -0,0 +1,183 @@
+package ui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/staylor11x/spider-solitaire/internal/deck"
+	"github.com/staylor11x/spider-solitaire/internal/game"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// act runs an engine action and plans its animation
+func act(t *testing.T, g *game.GameState, action func() error) animation {
+	t.Helper()
+	before := g.View()
+	require.NoError(t, action())
+	return planAnimation(&DefaultTheme, before, g.View(), g.LastEvents())
+}
+
+func TestPlanAnimation_MoveThenReveal(t *testing.T) {
+	timing := DefaultTheme.Timing
+	g, err := game.DealSeededGame(deck.OneSuit, 1)
+	require.NoError(t, err)
+
+	// 0:5>4 moves pile 0's top card onto pile 4 and reveals the card beneath it
+	an := act(t, g, func() error { return g.MoveSequence(0, 5, 4) })
+	require.Len(t, an.sprites, 2)
+	assert.Equal(t, timing.Move+timing.Flip, an.end)
+
+	moved := an.sprites[0]
+	assert.Equal(t, []*sprite{moved}, an.moved)
+	require.Len(t, moved.legs, 1)
+	assert.Equal(t, pilePos(&DefaultTheme, 0, 5, 6), moved.legs[0].from)
+	assert.Equal(t, pilePos(&DefaultTheme, 4, 5, 6), moved.legs[0].to)
+	assert.Same(t, moved, an.slots[4][5])
+
+	flip := an.sprites[1]
+	assert.True(t, flip.legs[0].flip)
+	assert.Equal(t, timing.Move, flip.legs[0].start, "the reveal waits for the move")
+	assert.False(t, flip.faceUp)
+	assert.True(t, flip.card.FaceUp)
+	assert.Same(t, flip, an.slots[0][4])
+	assert.Nil(t, an.slots[0][3], "cards that don't move are drawn by the view")
+}
+
+func TestPlanAnimation_DealFansFromStock(t *testing.T) {
+	timing := DefaultTheme.Timing
+	g, err := game.DealSeededGame(deck.OneSuit, 1)
+	require.NoError(t, err)
+
+	an := act(t, g, g.DealRow)
+	require.Len(t, an.sprites, game.TableauPiles)
+	stock := vec{float64(DefaultTheme.Layout.StockX), float64(DefaultTheme.Layout.StockY)}
+	view := g.View()
+	for p, s := range an.sprites {
+		n := len(view.Tableau[p].Cards)
+		assert.Equal(t, stock, s.legs[0].from)
+		assert.Equal(t, pilePos(&DefaultTheme, p, n-1, n), s.legs[0].to)
+		assert.Equal(t, time.Duration(p)*timing.DealStagger, s.legs[0].start)
+		assert.Equal(t, view.Tableau[p].Cards[n-1], s.card)
+	}
+	assert.Equal(t, 9*timing.DealStagger+timing.Deal, an.end)
+}
+
+func TestPlanAnimation_NothingToAnimate(t *testing.T) {
+	g, err := game.DealSeededGame(deck.OneSuit, 1)
+	require.NoError(t, err)
+	require.NoError(t, g.DealRow())
+
+	an := act(t, g, g.Undo)
+	assert.Empty(t, an.sprites, "undo jumps straight back")
+
+	still := &Theme{Layout: DefaultTheme.Layout}
+	before := g.View()
+	require.NoError(t, g.DealRow())
+	assert.Empty(t, planAnimation(still, before, g.View(), g.LastEvents()).sprites, "zero timings disable animation")
+}
+
+func TestPlanAnimation_CompletedRunFliesToFoundation(t *testing.T) {
+	timing := DefaultTheme.Timing
+	card := func(r deck.Rank) game.CardDTO { return testCard(r, deck.Hearts, true) }
+
+	// King..2 on pile 0, the Ace on pile 1; moving the Ace completes the run
+	before := game.GameViewDTO{Tableau: make([]game.PileDTO, game.TableauPiles), CompletedCount: 2}
+	for r := deck.King; r >= deck.Two; r-- {
+		before.Tableau[0].Cards = append(before.Tableau[0].Cards, card(r))
+	}
+	before.Tableau[1].Cards = []game.CardDTO{card(deck.Ace)}
+	after := game.GameViewDTO{Tableau: make([]game.PileDTO, game.TableauPiles), CompletedCount: 3}
+	events := []game.Event{
+		{Kind: game.EventMoveApplied, Move: game.TableauMove(1, 0, 0), Count: 1},
+		{Kind: game.EventRunCompleted, Pile: 0, Card: deck.Card{Suit: deck.Hearts, Rank: deck.King}},
+	}
+
+	an := planAnimation(&DefaultTheme, before, after, events)
+	require.Len(t, an.sprites, game.RunLength)
+	fx, fy := foundationSlot(&DefaultTheme, 2)
+	for _, s := range an.sprites {
+		assert.Equal(t, vec{float64(fx), float64(fy)}, s.legs[len(s.legs)-1].to)
+	}
+	ace := an.sprites[0]
+	require.Len(t, ace.legs, 2, "the Ace slides onto the run, then flies off with it")
+	assert.Equal(t, timing.Move, ace.legs[1].start, "and leaves first")
+	assert.Equal(t, timing.Move+12*timing.RunStagger+timing.Run, an.end)
+	assert.Empty(t, an.slots[0])
+	assert.Equal(t, 1, an.runs)
+}
+
+func TestPlanAnimation_AutoCompletePlaysMovesInTurn(t *testing.T) {
+	timing := DefaultTheme.Timing
+	g := &game.GameState{}
+	for range game.TotalRunsToWin - 2 {
+		g.Completed = append(g.Completed, game.CompletedRun(deck.Clubs))
+	}
+	// the low hearts sit on the spades, so they have to go first
+	spades, hearts := game.CompletedRun(deck.Spades), game.CompletedRun(deck.Hearts)
+	g.Tableau.Piles[0].AddCards(spades[:6])
+	g.Tableau.Piles[0].AddCards(hearts[9:])
+	g.Tableau.Piles[1].AddCards(spades[6:])
+	g.Tableau.Piles[2].AddCards(hearts[:9])
+
+	an := act(t, g, g.AutoComplete)
+	assert.Equal(t, 2, an.runs)
+	require.Len(t, an.moved, 4+7)
+	runTime := time.Duration(game.RunLength-1)*timing.RunStagger + timing.Run
+	assert.Equal(t, time.Duration(0), an.moved[0].legs[0].start)
+	assert.Equal(t, timing.Move+runTime, an.moved[4].legs[0].start, "the second move waits for the first run to leave")
+	assert.Equal(t, 2*(timing.Move+runTime), an.end)
+}
+
+func TestSprite_At(t *testing.T) {
+	s := &sprite{legs: []leg{
+		{from: vec{0, 0}, to: vec{100, 0}, start: 10, end: 20},
+		{from: vec{100, 0}, to: vec{100, 0}, start: 30, end: 40, flip: true},
+	}}
+
+	pos, _, up := s.at(0)
+	assert.Equal(t, vec{0, 0}, pos, "waits at the start")
+	assert.False(t, up)
+
+	pos, _, _ = s.at(25)
+	assert.Equal(t, vec{100, 0}, pos, "rests between legs")
+
+	_, scale, up := s.at(34)
+	assert.InDelta(t, 0.2, scale, 1e-9, "narrows while flipping")
+	assert.False(t, up)
+	_, _, up = s.at(36)
+	assert.True(t, up, "shows the face after half the flip")
+
+	pos, scale, up = s.at(50)
+	assert.Equal(t, vec{100, 0}, pos)
+	assert.Equal(t, 1.0, scale)
+	assert.True(t, up)
+}
+
+func TestAnimator_ClockDriven(t *testing.T) {
+	g, err := game.DealSeededGame(deck.OneSuit, 1)
+	require.NoError(t, err)
+	now := time.Unix(0, 0)
+	a := animator{now: func() time.Time { return now }}
+
+	view := g.View()
+	a.play(act(t, g, func() error { return g.MoveSequence(0, 5, 4) }))
+	view = g.View()
+	assert.True(t, a.active())
+
+	hidden := a.hiddenFrom(view)
+	assert.Equal(t, 4, hidden[0], "the revealed card is hidden until it flips")
+	assert.Equal(t, 5, hidden[4], "the moved card is hidden until it lands")
+	assert.Equal(t, len(view.Tableau[1].Cards), hidden[1])
+
+	now = now.Add(DefaultTheme.Timing.Move)
+	assert.Equal(t, len(view.Tableau[4].Cards), a.hiddenFrom(view)[4], "landed")
+
+	now = now.Add(time.Hour)
+	assert.False(t, a.active())
+	assert.Nil(t, a.hiddenFrom(view))
+
+	a.play(act(t, g, g.DealRow))
+	a.finish()
+	assert.False(t, a.active(), "input fast-forwards")
+}
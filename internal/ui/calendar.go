@@ -0,0 +1,82 @@
+package ui
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/staylor11x/spider-solitaire/internal/daily"
+	"github.com/staylor11x/spider-solitaire/internal/deck"
+	"github.com/staylor11x/spider-solitaire/internal/stats"
+)
+
+// calendarWeekdays heads the calendar columns; weeks start on Monday
+var calendarWeekdays = [7]string{"M", "T", "W", "T", "F", "S", "S"}
+
+// calendarWeeks lays out the month containing day as rows of a week, Monday first.
+// Each entry is a day of the month, or 0 for the blanks before the 1st and after the last day.
+func calendarWeeks(day time.Time) [][7]int {
+	first := time.Date(day.Year(), day.Month(), 1, 0, 0, 0, 0, day.Location())
+	days := first.AddDate(0, 1, -1).Day()
+	col := (int(first.Weekday()) + 6) % 7 // Monday is column 0
+
+	var weeks [][7]int
+	var week [7]int
+	for d := 1; d <= days; d++ {
+		week[col] = d
+		if col++; col == 7 {
+			weeks = append(weeks, week)
+			week, col = [7]int{}, 0
+		}
+	}
+	if col > 0 {
+		weeks = append(weeks, week)
+	}
+	return weeks
+}
+
+// drawCalendar draws the month of today with its top-left corner at x, y, filling in the
+// days whose challenge was won at suits and outlining today
+func drawCalendar(screen *ebiten.Image, x, y int, today time.Time, log *daily.Log, suits deck.SuitCount, theme *Theme) {
+	cell := theme.Layout.CalendarCell
+	step := cell + theme.Layout.CalendarGap
+	centred := text.LayoutOptions{PrimaryAlign: text.AlignCenter, SecondaryAlign: text.AlignCenter}
+	label := func(s string, cx, cy int) {
+		opts := &text.DrawOptions{LayoutOptions: centred}
+		opts.GeoM.Translate(float64(cx), float64(cy))
+		opts.ColorScale.ScaleWithColor(theme.Colors.MenuItemText)
+		text.Draw(screen, s, theme.Font, opts)
+	}
+
+	width := 7*step - theme.Layout.CalendarGap
+	label(today.Format("January 2006"), x+width/2, y+cell/4)
+	for i, wd := range calendarWeekdays {
+		label(wd, x+i*step+cell/2, y+cell*3/4)
+	}
+
+	top := y + cell
+	for row, week := range calendarWeeks(today) {
+		for col, d := range week {
+			if d == 0 {
+				continue
+			}
+			cx, cy := float32(x+col*step), float32(top+row*step)
+			date := time.Date(today.Year(), today.Month(), d, 0, 0, 0, 0, today.Location())
+			bg := theme.Colors.CalendarDay
+			if e, ok := log.Lookup(date, suits); ok {
+				bg = theme.Colors.CalendarPlayed
+				if e.Result == stats.Won {
+					bg = theme.Colors.CalendarWon
+				}
+			}
+			vector.FillRect(screen, cx, cy, float32(cell), float32(cell), bg, false)
+			if d == today.Day() {
+				border := float32(theme.Layout.FocusBorderPx)
+				vector.StrokeRect(screen, cx, cy, float32(cell), float32(cell), border, theme.Colors.FocusBorder, false)
+			}
+			label(strconv.Itoa(d), int(cx)+cell/2, int(cy)+cell/2)
+		}
+	}
+}
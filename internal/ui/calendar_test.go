@@ -0,0 +1,27 @@
+package ui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCalendarWeeks(t *testing.T) {
+	// October 2026 starts on a Thursday and has 31 days
+	weeks := calendarWeeks(time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC))
+	assert.Len(t, weeks, 5)
+	assert.Equal(t, [7]int{0, 0, 0, 1, 2, 3, 4}, weeks[0])
+	assert.Equal(t, [7]int{26, 27, 28, 29, 30, 31, 0}, weeks[4])
+
+	// February 2027 starts on a Monday and fills four weeks exactly
+	weeks = calendarWeeks(time.Date(2027, 2, 1, 0, 0, 0, 0, time.UTC))
+	assert.Len(t, weeks, 4)
+	assert.Equal(t, [7]int{22, 23, 24, 25, 26, 27, 28}, weeks[3])
+
+	// a month starting on a Sunday needs six rows
+	weeks = calendarWeeks(time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC))
+	assert.Len(t, weeks, 6)
+	assert.Equal(t, [7]int{0, 0, 0, 0, 0, 0, 1}, weeks[0])
+	assert.Equal(t, [7]int{30, 31, 0, 0, 0, 0, 0}, weeks[5])
+}
@@ -10,10 +10,14 @@ import (
 	"github.com/staylor11x/spider-solitaire/internal/deck"
 )
 
+// CardBacks lists the card back colours in the assets; the first is the default
+var CardBacks = []string{"black", "blue", "red", "purple", "orange"}
+
 type CardAtlas struct {
-	fs    fs.FS
-	cache map[string]*ebiten.Image
-	back  *ebiten.Image
+	fs       fs.FS
+	cache    map[string]*ebiten.Image
+	back     *ebiten.Image
+	backName string
 }
 
 func NewCardAtlas(fsys fs.FS) (*CardAtlas, error) {
@@ -21,11 +25,9 @@ func NewCardAtlas(fsys fs.FS) (*CardAtlas, error) {
 		fs:    fsys,
 		cache: make(map[string]*ebiten.Image),
 	}
-	b, err := a.loadPNG("images/card-back/card-back-black.png")
-	if err != nil {
-		return nil, fmt.Errorf("load card back: %w", err)
+	if err := a.SetBack(CardBacks[0]); err != nil {
+		return nil, err
 	}
-	a.back = b
 	return a, nil
 }
 
@@ -33,6 +35,21 @@ func (a *CardAtlas) Back() *ebiten.Image {
 	return a.back
 }
 
+// BackName returns the colour of the card back in use
+func (a *CardAtlas) BackName() string {
+	return a.backName
+}
+
+// SetBack switches the card back to one of CardBacks
+func (a *CardAtlas) SetBack(name string) error {
+	b, err := a.loadPNG(fmt.Sprintf("images/card-back/card-back-%s.png", name))
+	if err != nil {
+		return fmt.Errorf("load card back %q: %w", name, err)
+	}
+	a.back, a.backName = b, name
+	return nil
+}
+
 func (a *CardAtlas) Card(suit, rank int) (*ebiten.Image, error) {
 	name := fmt.Sprintf("images/cards/%s", filenameForCard(suit, rank))
 	if img, ok := a.cache[name]; ok {
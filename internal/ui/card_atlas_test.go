@@ -65,3 +65,17 @@ func TestCardAtlas_Caching(t *testing.T) {
 
 	assert.Same(t, img1, img2) // should be the same pointer
 }
+
+func TestCardAtlas_SetBack(t *testing.T) {
+	atlas, err := NewCardAtlas(assets.Files)
+	assert.NoError(t, err)
+	assert.Equal(t, CardBacks[0], atlas.BackName())
+
+	for _, name := range CardBacks {
+		assert.NoError(t, atlas.SetBack(name), name)
+		assert.Equal(t, name, atlas.BackName())
+	}
+
+	assert.Error(t, atlas.SetBack("plaid"))
+	assert.Equal(t, CardBacks[len(CardBacks)-1], atlas.BackName(), "a failed switch keeps the old back")
+}
@@ -0,0 +1,146 @@
+package ui
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/staylor11x/spider-solitaire/internal/logger"
+)
+
+// keyFocus is the keyboard cursor: a pile and the card in it that Enter would pick up.
+// It stays hidden until a cursor key is pressed and hides again when the mouse is used.
+type keyFocus struct {
+	shown bool
+	pile  int
+	card  int // index within the pile; 0 for an empty pile
+}
+
+// pileKeys jump straight to a pile. Piles count from 0, as in the printer and the CLI.
+var pileKeys = [...][2]ebiten.Key{
+	{ebiten.Key0, ebiten.KeyNumpad0},
+	{ebiten.Key1, ebiten.KeyNumpad1},
+	{ebiten.Key2, ebiten.KeyNumpad2},
+	{ebiten.Key3, ebiten.KeyNumpad3},
+	{ebiten.Key4, ebiten.KeyNumpad4},
+	{ebiten.Key5, ebiten.KeyNumpad5},
+	{ebiten.Key6, ebiten.KeyNumpad6},
+	{ebiten.Key7, ebiten.KeyNumpad7},
+	{ebiten.Key8, ebiten.KeyNumpad8},
+	{ebiten.Key9, ebiten.KeyNumpad9},
+}
+
+// handleCursorKeys moves the keyboard cursor and picks up or drops cards with it
+func (p *playScene) handleCursorKeys() {
+	switch {
+	case inpututil.IsKeyJustPressed(ebiten.KeyArrowLeft):
+		p.focusStep(-1)
+	case inpututil.IsKeyJustPressed(ebiten.KeyArrowRight):
+		p.focusStep(1)
+	case inpututil.IsKeyJustPressed(ebiten.KeyArrowUp):
+		p.focusUp()
+	case inpututil.IsKeyJustPressed(ebiten.KeyArrowDown):
+		p.focusDown()
+	case inpututil.IsKeyJustPressed(ebiten.KeyEnter), inpututil.IsKeyJustPressed(ebiten.KeyNumpadEnter), inpututil.IsKeyJustPressed(ebiten.KeySpace):
+		p.focusActivate()
+	}
+	for i, keys := range pileKeys {
+		if inpututil.IsKeyJustPressed(keys[0]) || inpututil.IsKeyJustPressed(keys[1]) {
+			p.focusPile(i)
+		}
+	}
+}
+
+// focusPile puts the cursor on the top card of a pile, ignoring piles that don't exist
+func (p *playScene) focusPile(pile int) {
+	if pile < 0 || pile >= len(p.view.Tableau) {
+		return
+	}
+	p.focus = keyFocus{shown: true, pile: pile, card: max(len(p.view.Tableau[pile].Cards)-1, 0)}
+}
+
+// focusStep moves the cursor dir piles left or right, wrapping at the edges.
+// The first key press only shows the cursor where it was.
+func (p *playScene) focusStep(dir int) {
+	n := len(p.view.Tableau)
+	if n == 0 {
+		return
+	}
+	if !p.focus.shown {
+		p.focusPile(min(max(p.focus.pile, 0), n-1))
+		return
+	}
+	p.focusPile(((p.focus.pile+dir)%n + n) % n)
+}
+
+// focusUp extends the cursor one card down the pile, as long as the cards from there
+// to the top still form a movable sequence. While carrying cards the cursor only picks
+// a destination pile, so it stays on the top card.
+func (p *playScene) focusUp() {
+	if !p.focus.shown || p.selecting {
+		p.focusStep(0)
+		return
+	}
+	cards := p.view.Tableau[p.focus.pile].Cards
+	if computeMovableHoverEnd(cards, p.focus.card-1) >= 0 {
+		p.focus.card--
+	}
+}
+
+// focusDown shrinks the cursor back towards the top card
+func (p *playScene) focusDown() {
+	if !p.focus.shown {
+		p.focusStep(0)
+		return
+	}
+	if p.focus.card < len(p.view.Tableau[p.focus.pile].Cards)-1 {
+		p.focus.card++
+	}
+}
+
+// focusActivate picks up the cards under the cursor, or drops the carried cards on the
+// cursor's pile and moves the cursor onto them. Dropping them back where they came from
+// puts them down again.
+func (p *playScene) focusActivate() {
+	if !p.focus.shown {
+		p.focusStep(0)
+		return
+	}
+	if !p.selecting {
+		p.selectCard(p.focus.pile, p.focus.card)
+		return
+	}
+	if p.focus.pile == p.selectedPile {
+		logger.Debug("Selection put back via keyboard")
+		p.clearSelection()
+		return
+	}
+	n := len(p.view.Tableau[p.focus.pile].Cards)
+	p.moveSelection(p.focus.pile)
+	// follow the cards that were put down; clampFocus falls back to the top if none were
+	p.focus.card = n
+	p.clampFocus()
+}
+
+// clampFocus keeps the cursor on a movable card after the piles change, falling back to
+// the top card of its pile
+func (p *playScene) clampFocus() {
+	if p.focus.pile < 0 || p.focus.pile >= len(p.view.Tableau) {
+		p.focus = keyFocus{}
+		return
+	}
+	cards := p.view.Tableau[p.focus.pile].Cards
+	if computeMovableHoverEnd(cards, p.focus.card) < 0 {
+		p.focus.card = max(len(cards)-1, 0)
+	}
+}
+
+// focusTarget is the pile and card drawTableau outlines for the cursor, or -1, -1 when hidden.
+// While carrying cards only the destination's top card is outlined.
+func (p *playScene) focusTarget() (pile, card int) {
+	if !p.focus.shown {
+		return -1, -1
+	}
+	if p.selecting {
+		return p.focus.pile, len(p.view.Tableau[p.focus.pile].Cards) - 1
+	}
+	return p.focus.pile, p.focus.card
+}
@@ -0,0 +1,103 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/staylor11x/spider-solitaire/internal/deck"
+	"github.com/staylor11x/spider-solitaire/internal/game"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFocusStep_ShowsThenWraps(t *testing.T) {
+	p := testPlayScene(t)
+	n := len(p.view.Tableau)
+
+	p.focusStep(-1)
+	assert.True(t, p.focus.shown)
+	assert.Equal(t, 0, p.focus.pile, "the first press only shows the cursor")
+	assert.Equal(t, len(p.view.Tableau[0].Cards)-1, p.focus.card, "the cursor starts on the top card")
+
+	p.focusStep(-1)
+	assert.Equal(t, n-1, p.focus.pile)
+	p.focusStep(1)
+	assert.Equal(t, 0, p.focus.pile)
+}
+
+func TestFocusPile_JumpsByIndex(t *testing.T) {
+	p := testPlayScene(t)
+	p.focusPile(7)
+	assert.Equal(t, keyFocus{shown: true, pile: 7, card: len(p.view.Tableau[7].Cards) - 1}, p.focus)
+
+	p.focusPile(len(p.view.Tableau))
+	assert.Equal(t, 7, p.focus.pile, "piles that don't exist are ignored")
+}
+
+func TestFocusUp_StaysWithinMovableCards(t *testing.T) {
+	p := testPlayScene(t)
+	p.view.Tableau[2].Cards = []game.CardDTO{
+		testCard(deck.Nine, deck.Spades, false),
+		testCard(deck.Two, deck.Spades, true),
+		testCard(deck.Seven, deck.Spades, true),
+		testCard(deck.Six, deck.Spades, true),
+	}
+	p.focusPile(2)
+
+	p.focusUp()
+	assert.Equal(t, 2, p.focus.card)
+	p.focusUp()
+	assert.Equal(t, 2, p.focus.card, "the Two doesn't continue the sequence")
+
+	p.focusDown()
+	assert.Equal(t, 3, p.focus.card)
+	p.focusDown()
+	assert.Equal(t, 3, p.focus.card, "the cursor can't go past the top card")
+}
+
+func TestFocusActivate_PicksUpAndDrops(t *testing.T) {
+	p := testPlayScene(t)
+	var move game.Move
+	for _, m := range p.state.LegalMoves() {
+		if m.Kind == game.MoveTableau {
+			move = m
+			break
+		}
+	}
+
+	p.focusPile(move.Src)
+	for p.focus.card > move.Start {
+		p.focusUp()
+	}
+	assert.Equal(t, move.Start, p.focus.card)
+
+	p.focusActivate()
+	assert.True(t, p.selecting)
+	assert.Equal(t, move.Src, p.selectedPile)
+	assert.Equal(t, move.Start, p.selectedIndex)
+
+	p.focusPile(move.Dst)
+	dstLen := len(p.view.Tableau[move.Dst].Cards)
+	p.focusActivate()
+	assert.False(t, p.selecting)
+	assert.Equal(t, 1, p.view.Moves)
+	assert.Equal(t, dstLen, p.focus.card, "the cursor follows the cards that were put down")
+}
+
+func TestFocusActivate_PutsBackOnSamePile(t *testing.T) {
+	p := testPlayScene(t)
+	p.focusPile(0)
+	p.focusActivate()
+	assert.True(t, p.selecting)
+
+	p.focusActivate()
+	assert.False(t, p.selecting)
+	assert.Equal(t, 0, p.view.Moves)
+}
+
+func TestClampFocus_FallsBackToTopCard(t *testing.T) {
+	p := testPlayScene(t)
+	p.focusPile(4)
+	p.focus.card = 0 // face down in the deal
+
+	p.clampFocus()
+	assert.Equal(t, len(p.view.Tableau[4].Cards)-1, p.focus.card)
+}
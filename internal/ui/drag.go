@@ -0,0 +1,134 @@
+package ui
+
+import (
+	"image"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/staylor11x/spider-solitaire/internal/logger"
+)
+
+// dragThreshold is how far (in logical pixels) the cursor must travel with the
+// button held before a press on a card becomes a drag rather than a click
+const dragThreshold = 4
+
+// dragState tracks a press on a movable sequence that may turn into a drag.
+// The sequence being dragged is the current selection (selectedPile/selectedIndex).
+type dragState struct {
+	pressed bool        // the button has been held since pressing on a movable sequence
+	active  bool        // the cursor moved far enough that this is a drag
+	origin  image.Point // where the press started
+	grab    image.Point // cursor offset from the top-left of the grabbed card
+	cursor  image.Point // latest cursor position, for drawing
+	targets []int       // piles the sequence can legally be dropped on
+}
+
+// startPress arms a drag after a press has selected the card at pile/cardIdx.
+// Only sequences the engine could move are draggable; others stay click-only.
+func (p *playScene) startPress(pile, cardIdx, mx, my int) {
+	cards := p.view.Tableau[pile].Cards
+	if computeMovableHoverEnd(cards, cardIdx) < 0 {
+		return
+	}
+	x := p.theme.Layout.TableauStartX + pile*p.theme.Layout.PileSpacing
+	y := computeTableauPileLayout(p.theme, len(cards)).CardY[cardIdx]
+	p.drag = dragState{
+		pressed: true,
+		origin:  image.Pt(mx, my),
+		grab:    image.Pt(mx-x, my-y),
+		cursor:  image.Pt(mx, my),
+	}
+}
+
+// updateDrag follows the cursor while the button is held and drops on release.
+// A press released without moving is a plain click and leaves the selection for click-to-move.
+func (p *playScene) updateDrag() {
+	if !p.selecting {
+		p.drag = dragState{} // the selection was dropped by a key press mid-drag
+		return
+	}
+	mx, my := p.logicalCursor()
+	p.drag.cursor = image.Pt(mx, my)
+
+	if inpututil.IsMouseButtonJustReleased(ebiten.MouseButtonLeft) || !ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
+		if p.drag.active {
+			p.drop(mx, my)
+		} else {
+			p.drag = dragState{}
+		}
+		return
+	}
+
+	if !p.drag.active && exceedsThreshold(p.drag.origin, p.drag.cursor) {
+		p.drag.active = true
+		p.drag.targets = p.dropTargets()
+		p.showHint = false
+		logger.Debug("Drag: start (pile=%d, idx=%d, targets=%v)", p.selectedPile, p.selectedIndex, p.drag.targets)
+	}
+}
+
+// exceedsThreshold reports whether b is more than dragThreshold from a
+func exceedsThreshold(a, b image.Point) bool {
+	d := b.Sub(a)
+	return d.X*d.X+d.Y*d.Y > dragThreshold*dragThreshold
+}
+
+// dropTargets lists the piles the selected sequence could legally move to
+func (p *playScene) dropTargets() []int {
+	var targets []int
+	for dst := range p.view.Tableau {
+		if dst != p.selectedPile && p.state.CanMove(p.selectedPile, p.selectedIndex, dst) == nil {
+			targets = append(targets, dst)
+		}
+	}
+	return targets
+}
+
+// drop moves the dragged sequence to the pile under the cursor. Dropping anywhere
+// else, or on an illegal pile, snaps the cards back by simply ending the drag.
+func (p *playScene) drop(mx, my int) {
+	defer p.clearSelection()
+
+	dst, ok := p.pileAt(mx, my)
+	if !ok || dst == p.selectedPile {
+		logger.Debug("Drag: cancelled")
+		return
+	}
+	logger.Debug("Move: drop %d:%d -> %d", p.selectedPile, p.selectedIndex, dst)
+	dx, dy := p.dragOffset()
+	if err := p.performMove(p.selectedPile, p.selectedIndex, dst); err != nil {
+		p.setError(err.Error())
+		logger.Error("Move: error: %s", err.Error())
+		return
+	}
+	p.refreshView()
+	p.anim.dropped(float64(dx), float64(dy-p.theme.Layout.SelectionLiftPx))
+	logger.Info("Move: success %d:%d -> %d (completed=%d)", p.selectedPile, p.selectedIndex, dst, p.view.CompletedCount)
+}
+
+// pileAt returns the pile a drop at (mx, my) lands on: the card under the cursor when
+// there is one, otherwise the column the cursor is in, anywhere below the top of the tableau
+func (p *playScene) pileAt(mx, my int) (int, bool) {
+	if pile, _, ok := p.hitTest(mx, my); ok {
+		return pile, true
+	}
+	l := p.theme.Layout
+	if my < l.TableauStartY {
+		return 0, false
+	}
+	for i := range p.view.Tableau {
+		x := l.TableauStartX + i*l.PileSpacing
+		if mx >= x && mx < x+l.CardWidth {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// dragOffset is how far the dragged cards are drawn from their place in the pile
+func (p *playScene) dragOffset() (dx, dy int) {
+	cards := p.view.Tableau[p.selectedPile].Cards
+	x := p.theme.Layout.TableauStartX + p.selectedPile*p.theme.Layout.PileSpacing
+	y := computeTableauPileLayout(p.theme, len(cards)).CardY[p.selectedIndex]
+	return p.drag.cursor.X - p.drag.grab.X - x, p.drag.cursor.Y - p.drag.grab.Y - y
+}
@@ -0,0 +1,124 @@
+package ui
+
+import (
+	"image"
+	"testing"
+
+	"github.com/staylor11x/spider-solitaire/internal/deck"
+	"github.com/staylor11x/spider-solitaire/internal/game"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testPlayScene builds a play scene around a seeded deal without touching Ebiten
+func testPlayScene(t *testing.T) *playScene {
+	t.Helper()
+	state, err := game.DealSeededGame(deck.OneSuit, 1)
+	require.NoError(t, err)
+	p := &playScene{state: state, theme: &DefaultTheme}
+	p.refreshView()
+	p.clearSelection()
+	return p
+}
+
+func TestExceedsThreshold(t *testing.T) {
+	o := image.Pt(100, 100)
+	assert.False(t, exceedsThreshold(o, o))
+	assert.False(t, exceedsThreshold(o, image.Pt(103, 100)))
+	assert.True(t, exceedsThreshold(o, image.Pt(100, 105)))
+}
+
+func TestPileAt_UsesCompressedLayout(t *testing.T) {
+	p := testPlayScene(t)
+	l := p.theme.Layout
+
+	// a tall pile is compressed; its top card must still be found where it is drawn
+	var cards []game.CardDTO
+	for range 40 {
+		cards = append(cards, testCard(deck.Five, deck.Spades, true))
+	}
+	p.view.Tableau[3].Cards = cards
+	top := computeTableauPileLayout(p.theme, len(cards)).CardY[len(cards)-1]
+	x := l.TableauStartX + 3*l.PileSpacing
+
+	pile, ok := p.pileAt(x+1, top+l.CardHeight-1)
+	assert.True(t, ok)
+	assert.Equal(t, 3, pile)
+
+	// below the cards in the same column still counts as that pile
+	pile, ok = p.pileAt(x+1, l.LogicalHeight-1)
+	assert.True(t, ok)
+	assert.Equal(t, 3, pile)
+
+	_, ok = p.pileAt(x+l.CardWidth+1, top)
+	assert.False(t, ok, "the gap between columns is not a pile")
+	_, ok = p.pileAt(x+1, l.TableauStartY-1)
+	assert.False(t, ok, "above the tableau is not a pile")
+}
+
+func TestDropTargets_MatchEngine(t *testing.T) {
+	p := testPlayScene(t)
+	for _, m := range p.state.LegalMoves() {
+		if m.Kind != game.MoveTableau {
+			continue
+		}
+		p.selecting, p.selectedPile, p.selectedIndex = true, m.Src, m.Start
+		assert.Contains(t, p.dropTargets(), m.Dst, "legal move %s", m)
+		assert.NotContains(t, p.dropTargets(), m.Src)
+		return
+	}
+	t.Fatal("the deal should have a tableau move")
+}
+
+func TestDrop_IllegalSnapsBack(t *testing.T) {
+	p := testPlayScene(t)
+	before := p.view
+	l := p.theme.Layout
+
+	// pile 0's top card onto itself, then somewhere that is not a pile
+	top := len(p.view.Tableau[0].Cards) - 1
+	for _, pt := range []image.Point{{l.TableauStartX + 1, l.TableauStartY + 1}, {0, 0}} {
+		p.selecting, p.selectedPile, p.selectedIndex = true, 0, top
+		p.drag = dragState{pressed: true, active: true}
+		p.drop(pt.X, pt.Y)
+		assert.False(t, p.selecting)
+		assert.Equal(t, dragState{}, p.drag)
+		assert.Equal(t, before, p.view, "nothing moved")
+	}
+}
+
+func TestDrop_LegalMoves(t *testing.T) {
+	p := testPlayScene(t)
+	var move game.Move
+	for _, m := range p.state.LegalMoves() {
+		if m.Kind == game.MoveTableau {
+			move = m
+			break
+		}
+	}
+	l := p.theme.Layout
+	p.selecting, p.selectedPile, p.selectedIndex = true, move.Src, move.Start
+	p.drag = dragState{pressed: true, active: true}
+	p.drop(l.TableauStartX+move.Dst*l.PileSpacing+1, l.LogicalHeight-1)
+
+	assert.Equal(t, 1, p.view.Moves)
+	assert.False(t, p.selecting)
+}
+
+func TestDragOffset_KeepsGrabPoint(t *testing.T) {
+	p := testPlayScene(t)
+	l := p.theme.Layout
+	pile := 4
+	idx := len(p.view.Tableau[pile].Cards) - 1
+	x := l.TableauStartX + pile*l.PileSpacing
+	y := computeTableauPileLayout(p.theme, idx+1).CardY[idx]
+
+	p.selecting, p.selectedPile, p.selectedIndex = true, pile, idx
+	p.startPress(pile, idx, x+10, y+20)
+	require.True(t, p.drag.pressed, "a single face-up card is draggable")
+
+	p.drag.cursor = image.Pt(x+60, y+45)
+	dx, dy := p.dragOffset()
+	assert.Equal(t, 50, dx)
+	assert.Equal(t, 25, dy)
+}
@@ -0,0 +1,65 @@
+package ui
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/staylor11x/spider-solitaire/internal/deck"
+	"github.com/staylor11x/spider-solitaire/internal/game"
+)
+
+// foundationSlots is one slot per run needed to win
+const foundationSlots = game.TotalRunsToWin
+
+// foundationLayout places the completed-run slots in the bottom-left corner
+type foundationLayout struct {
+	X, Y int // top-left of the first slot
+	Step int // horizontal distance between slots; they overlap when it is less than a card
+}
+
+// computeFoundationLayout lines the slots up from the left edge of the tableau towards the
+// stock, level with the stock and never below the bottom of the screen. Slots close up when
+// the space before the stock is too narrow, so the area fits any logical screen size.
+func computeFoundationLayout(theme *Theme) foundationLayout {
+	l := theme.Layout
+	x := l.TableauStartX
+	y := min(l.StockY, l.LogicalHeight-l.CardHeight)
+
+	step := l.FoundationStep
+	available := l.StockX - l.FoundationGap - x - l.CardWidth
+	if fit := available / (foundationSlots - 1); fit < step {
+		step = max(fit, 1)
+	}
+	return foundationLayout{X: x, Y: y, Step: step}
+}
+
+// slot returns the top-left of slot i (counting from 0)
+func (f foundationLayout) slot(i int) (x, y int) {
+	return f.X + i*f.Step, f.Y
+}
+
+// foundationSlot is the top-left of the slot the i-th completed run (counting from 0) goes to
+func foundationSlot(theme *Theme, i int) (x, y int) {
+	return computeFoundationLayout(theme).slot(i)
+}
+
+// drawFoundation renders the completed-run area: a faint outline around all slots and, for
+// each completed run, its King stacked left to right. flash (0 to 1) highlights the newest run.
+func drawFoundation(screen *ebiten.Image, suits []game.SuitDTO, atlas *CardAtlas, theme *Theme, flash float64) {
+	f := computeFoundationLayout(theme)
+	w := float32(f.Step*(foundationSlots-1) + theme.Layout.CardWidth)
+	h := float32(theme.Layout.CardHeight)
+	vector.FillRect(screen, float32(f.X), float32(f.Y), w, h, theme.Colors.PlaceholderBG, false)
+	vector.StrokeRect(screen, float32(f.X), float32(f.Y), w, h, float32(theme.Layout.PlaceholderBorderPx), theme.Colors.PlaceholderBorder, false)
+
+	for i, suit := range suits {
+		x, y := f.slot(i)
+		drawCard(screen, game.CardDTO{Rank: game.RankDTO(deck.King), Suit: suit, FaceUp: true}, x, y, atlas, theme)
+	}
+
+	if flash > 0 && len(suits) > 0 {
+		x, y := f.slot(len(suits) - 1)
+		c := theme.Colors.FoundationHighlight
+		c.A = uint8(float64(c.A) * flash)
+		vector.StrokeRect(screen, float32(x), float32(y), float32(theme.Layout.CardWidth), h, float32(theme.Layout.HintBorderPx), c, false)
+	}
+}
@@ -0,0 +1,60 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// sizedTheme scales the default layout's screen, keeping the stock in the bottom-right corner
+func sizedTheme(width, height int) *Theme {
+	theme := DefaultTheme
+	l := &theme.Layout
+	l.StockX += width - l.LogicalWidth
+	l.StockY += height - l.LogicalHeight
+	l.LogicalWidth, l.LogicalHeight = width, height
+	return &theme
+}
+
+func TestComputeFoundationLayout_FitsEverySize(t *testing.T) {
+	sizes := []struct{ w, h int }{
+		{1280, 720}, {1920, 1080}, {1024, 768}, {800, 600}, {640, 480}, {480, 360},
+	}
+	for _, sz := range sizes {
+		theme := sizedTheme(sz.w, sz.h)
+		l := theme.Layout
+		f := computeFoundationLayout(theme)
+
+		firstX, y := f.slot(0)
+		lastX, _ := f.slot(foundationSlots - 1)
+		assert.Equal(t, l.TableauStartX, firstX, "%dx%d: starts under the first pile", sz.w, sz.h)
+		assert.Equal(t, l.StockY, y, "%dx%d: level with the stock", sz.w, sz.h)
+		assert.LessOrEqual(t, y+l.CardHeight, l.LogicalHeight, "%dx%d: on screen", sz.w, sz.h)
+		assert.LessOrEqual(t, lastX+l.CardWidth, l.StockX-l.FoundationGap, "%dx%d: clear of the stock", sz.w, sz.h)
+		assert.Positive(t, f.Step, "%dx%d: slots stay in order", sz.w, sz.h)
+	}
+}
+
+func TestComputeFoundationLayout_CompressesWhenNarrow(t *testing.T) {
+	wide := computeFoundationLayout(&DefaultTheme)
+	assert.Equal(t, DefaultTheme.Layout.FoundationStep, wide.Step, "room for the preferred spacing")
+
+	narrow := computeFoundationLayout(sizedTheme(480, 360))
+	assert.Less(t, narrow.Step, wide.Step)
+}
+
+func TestComputeFoundationLayout_StaysOnScreen(t *testing.T) {
+	theme := DefaultTheme
+	theme.Layout.StockY = theme.Layout.LogicalHeight // stock pushed off the bottom
+	_, y := computeFoundationLayout(&theme).slot(0)
+	assert.Equal(t, theme.Layout.LogicalHeight-theme.Layout.CardHeight, y)
+}
+
+func TestFoundationSlot_MatchesLayout(t *testing.T) {
+	f := computeFoundationLayout(&DefaultTheme)
+	for i := range foundationSlots {
+		x, y := foundationSlot(&DefaultTheme, i)
+		assert.Equal(t, f.X+i*f.Step, x)
+		assert.Equal(t, f.Y, y)
+	}
+}
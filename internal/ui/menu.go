@@ -0,0 +1,389 @@
+package ui
+
+import (
+	"fmt"
+	"image"
+	"strings"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/staylor11x/spider-solitaire/internal/daily"
+	"github.com/staylor11x/spider-solitaire/internal/deck"
+	"github.com/staylor11x/spider-solitaire/internal/game"
+	"github.com/staylor11x/spider-solitaire/internal/logger"
+	"github.com/staylor11x/spider-solitaire/internal/stats"
+	"github.com/staylor11x/spider-solitaire/internal/storage"
+)
+
+// menuItem is one button in a menu
+type menuItem struct {
+	label    string
+	disabled bool
+	action   func() error
+}
+
+// menu is a scene showing a title, optional notes and a column of buttons.
+// Arrow keys and Enter, or the mouse, pick an item; ESC runs back (when set).
+type menu struct {
+	theme  *Theme
+	title  string
+	notes  []string // lines of text between the title and the buttons
+	items  []menuItem
+	cursor int          // highlighted item
+	back   func() error // ESC action; nil to ignore ESC
+
+	lastMouse image.Point // hover only moves the cursor when the mouse moves
+	decorate  func(screen *ebiten.Image)
+}
+
+func newMenu(theme *Theme, title string, items []menuItem) *menu {
+	m := &menu{theme: theme, title: title, items: items, cursor: -1}
+	m.lastMouse = image.Pt(ebiten.CursorPosition())
+	m.move(1)
+	return m
+}
+
+// newMainMenu builds the title screen
+func newMainMenu(g *Game) *menu {
+	m := newMenu(g.theme, "Spider Solitaire", []menuItem{
+		{label: "New Game - 1 Suit", action: func() error { return g.newGame(deck.OneSuit) }},
+		{label: "New Game - 2 Suits", action: func() error { return g.newGame(deck.TwoSuits) }},
+		{label: "New Game - 4 Suits", action: func() error { return g.newGame(deck.FourSuits) }},
+		{label: "Continue", disabled: !g.canContinue(), action: g.resume},
+		{label: "Daily Challenge", action: func() error { g.switchTo(newDailyMenu(g)); return nil }},
+		{label: "Statistics", action: func() error { g.switchTo(newStatsMenu(g)); return nil }},
+		{label: "Settings", action: func() error { g.switchTo(newSettingsMenu(g)); return nil }},
+		{label: "Quit", action: g.quit},
+	})
+	if !m.items[3].disabled {
+		m.cursor = 3 // most visits are to carry on
+	}
+	if g.saveProblem != "" {
+		m.notes = append(m.notes, g.saveProblem)
+		g.saveProblem = ""
+	}
+	if g.play != nil && !g.play.over() {
+		m.back = g.resume // ESC goes back to the game
+	}
+	return m
+}
+
+// newStatsMenu shows the player's statistics for one difficulty at a time, and can
+// export them as CSV or reset them
+func newStatsMenu(g *Game) *menu {
+	m := newMenu(g.theme, "Statistics", nil)
+	var filter deck.SuitCount // zero shows every difficulty
+	var status string
+	confirming := false
+
+	log := &stats.Log{}
+	path, err := storage.StatsPath()
+	if err == nil {
+		log, err = storage.LoadStats(path)
+	}
+	if err != nil {
+		logger.Error("Stats: error: %s", err.Error())
+		log, status = &stats.Log{}, "Could not read statistics"
+	}
+
+	refresh := func() {
+		m.items[0].label = statsFilterLabel(filter)
+		m.items[2].label = "Reset Statistics"
+		if confirming {
+			m.items[2].label = "Press Again to Reset"
+		}
+		m.notes = statsLines(log.Summary(filter))
+		if status != "" {
+			m.notes = append(m.notes, status)
+		}
+	}
+	m.items = []menuItem{
+		{action: func() error {
+			filter, confirming, status = nextStatsFilter(filter), false, ""
+			refresh()
+			return nil
+		}},
+		{label: "Export CSV", action: func() error {
+			confirming = false
+			status = "Could not export statistics"
+			if csvPath, err := storage.StatsCSVPath(); err == nil && storage.ExportStatsCSV(csvPath, log) == nil {
+				status = "Exported to " + csvPath
+				logger.Info("Stats: exported %s", csvPath)
+			}
+			refresh()
+			return nil
+		}},
+		{action: func() error {
+			if confirming = !confirming; confirming {
+				refresh()
+				return nil
+			}
+			log.Reset()
+			status = "Statistics reset"
+			if err := storage.SaveStats(path, log); err != nil {
+				logger.Error("Stats: error: %s", err.Error())
+				status = "Could not reset statistics"
+			}
+			refresh()
+			return nil
+		}},
+		{label: "Back", action: func() error { g.showMenu(); return nil }},
+	}
+	m.items[2].disabled = path == ""
+	m.back = m.items[3].action
+	m.cursor = 0
+	refresh()
+	return m
+}
+
+// statsFilterOrder is the order the statistics screen cycles difficulties in
+var statsFilterOrder = []deck.SuitCount{0, deck.OneSuit, deck.TwoSuits, deck.FourSuits}
+
+// nextStatsFilter cycles through statsFilterOrder
+func nextStatsFilter(suits deck.SuitCount) deck.SuitCount {
+	for i, s := range statsFilterOrder {
+		if s == suits {
+			return statsFilterOrder[(i+1)%len(statsFilterOrder)]
+		}
+	}
+	return statsFilterOrder[0]
+}
+
+func statsFilterLabel(suits deck.SuitCount) string {
+	switch suits {
+	case 0:
+		return "Difficulty: All"
+	case deck.OneSuit:
+		return "Difficulty: 1 Suit"
+	}
+	return fmt.Sprintf("Difficulty: %d Suits", suits)
+}
+
+// statsLines is the summary shown on the statistics screen
+func statsLines(s stats.Summary) []string {
+	if s.Played == 0 {
+		return []string{"No games played yet."}
+	}
+	fastest, best := "-", "-"
+	if s.FastestWin > 0 {
+		fastest = formatElapsed(s.FastestWin)
+	}
+	if s.Won > 0 {
+		best = fmt.Sprint(s.BestScore)
+	}
+	return []string{
+		fmt.Sprintf("Played %d - Won %d (%.0f%%) - Lost %d - Abandoned %d", s.Played, s.Won, s.WinRate(), s.Lost, s.Abandoned),
+		fmt.Sprintf("Streak %d - Best streak %d - Fastest win %s - Best score %s", s.CurrentStreak, s.BestStreak, fastest, best),
+	}
+}
+
+// newDailyMenu shows this month's daily challenges at one difficulty, with the streak,
+// and starts today's
+func newDailyMenu(g *Game) *menu {
+	m := newMenu(g.theme, "Daily Challenge", nil)
+	suits := deck.OneSuit
+	today := time.Now()
+
+	log := &daily.Log{}
+	path, err := storage.DailyPath()
+	if err == nil {
+		log, err = storage.LoadDaily(path)
+	}
+	if err != nil {
+		logger.Error("Daily: error: %s", err.Error())
+		log = &daily.Log{}
+	}
+
+	refresh := func() {
+		m.items[1].label = statsFilterLabel(suits)
+		m.notes = dailyLines(log, suits, today)
+	}
+	m.items = []menuItem{
+		{label: "Play Today's Deal", action: func() error { return g.dailyGame(suits) }},
+		{action: func() error {
+			suits = nextDailySuits(suits)
+			refresh()
+			return nil
+		}},
+		{label: "Back", action: func() error { g.showMenu(); return nil }},
+	}
+	m.cursor = 0
+	m.back = m.items[2].action
+	m.decorate = func(screen *ebiten.Image) {
+		r := menuItemRect(g.theme.Layout, 0)
+		drawCalendar(screen, r.Max.X+g.theme.Layout.MenuItemGap*2, r.Min.Y, today, log, suits, g.theme)
+	}
+	refresh()
+	return m
+}
+
+// nextDailySuits cycles the daily challenge difficulties, which are the stats filters without "all"
+func nextDailySuits(suits deck.SuitCount) deck.SuitCount {
+	if next := nextStatsFilter(suits); next != 0 {
+		return next
+	}
+	return deck.OneSuit
+}
+
+// dailyLines is the streak and today's result shown on the daily challenge screen
+func dailyLines(log *daily.Log, suits deck.SuitCount, today time.Time) []string {
+	n := log.Streak(suits, today)
+	streak := fmt.Sprintf("Streak: %d days", n)
+	if n == 1 {
+		streak = "Streak: 1 day"
+	}
+	e, ok := log.Lookup(today, suits)
+	if !ok {
+		return []string{streak, "Today: not played yet"}
+	}
+	return []string{streak, fmt.Sprintf("Today: %s - %d moves - %s - score %d", e.Result, e.Moves, formatElapsed(e.Duration), e.Score)}
+}
+
+// newSettingsMenu lets the player change preferences, previewing the card back
+func newSettingsMenu(g *Game) *menu {
+	m := newMenu(g.theme, "Settings", nil)
+	m.items = []menuItem{
+		{label: cardBackLabel(g.atlas.BackName()), action: func() error {
+			next := nextCardBack(g.atlas.BackName())
+			if err := g.setCardBack(next); err != nil {
+				m.notes = []string{"Could not save settings"}
+				return nil
+			}
+			m.items[0].label = cardBackLabel(next)
+			return nil
+		}},
+		{label: "Back", action: func() error { g.showMenu(); return nil }},
+	}
+	m.cursor = 0
+	m.back = m.items[1].action
+	m.decorate = func(screen *ebiten.Image) {
+		r := menuItemRect(g.theme.Layout, 0)
+		x := r.Max.X + g.theme.Layout.MenuItemGap*2
+		// a zero card is face down, so it shows the current back
+		drawCard(screen, game.CardDTO{}, x, r.Min.Y, g.atlas, g.theme)
+	}
+	return m
+}
+
+func cardBackLabel(name string) string {
+	return "Card Back: " + strings.ToUpper(name[:1]) + name[1:]
+}
+
+// nextCardBack cycles through CardBacks
+func nextCardBack(name string) string {
+	for i, b := range CardBacks {
+		if b == name {
+			return CardBacks[(i+1)%len(CardBacks)]
+		}
+	}
+	return CardBacks[0]
+}
+
+// move steps the cursor to the next enabled item in direction dir (+1 or -1), wrapping
+func (m *menu) move(dir int) {
+	n := len(m.items)
+	for step := 1; step <= n; step++ {
+		i := ((m.cursor+dir*step)%n + n) % n
+		if !m.items[i].disabled {
+			m.cursor = i
+			return
+		}
+	}
+}
+
+// itemAt returns the enabled item under the point, or -1
+func (m *menu) itemAt(x, y int) int {
+	pt := image.Pt(x, y)
+	for i, it := range m.items {
+		if !it.disabled && pt.In(menuItemRect(m.theme.Layout, i)) {
+			return i
+		}
+	}
+	return -1
+}
+
+// menuItemRect is the button area of item i; buttons are centred in a column
+func menuItemRect(l Layout, i int) image.Rectangle {
+	x := (l.LogicalWidth - l.MenuItemWidth) / 2
+	y := l.MenuStartY + i*(l.MenuItemHeight+l.MenuItemGap)
+	return image.Rect(x, y, x+l.MenuItemWidth, y+l.MenuItemHeight)
+}
+
+func (m *menu) Update() error {
+	switch {
+	case inpututil.IsKeyJustPressed(ebiten.KeyDown), inpututil.IsKeyJustPressed(ebiten.KeyTab):
+		m.move(1)
+	case inpututil.IsKeyJustPressed(ebiten.KeyUp):
+		m.move(-1)
+	case inpututil.IsKeyJustPressed(ebiten.KeyEnter), inpututil.IsKeyJustPressed(ebiten.KeySpace):
+		return m.activate(m.cursor)
+	case inpututil.IsKeyJustPressed(ebiten.KeyEscape):
+		if m.back != nil {
+			return m.back()
+		}
+	}
+
+	mouse := image.Pt(ebiten.CursorPosition())
+	hovered := m.itemAt(mouse.X, mouse.Y)
+	if mouse != m.lastMouse && hovered >= 0 {
+		m.cursor = hovered
+	}
+	m.lastMouse = mouse
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) && hovered >= 0 {
+		return m.activate(hovered)
+	}
+	return nil
+}
+
+func (m *menu) activate(i int) error {
+	if i < 0 || i >= len(m.items) || m.items[i].disabled {
+		return nil
+	}
+	return m.items[i].action()
+}
+
+func (m *menu) Draw(screen *ebiten.Image) {
+	screen.Fill(m.theme.Colors.Background)
+	w := m.theme.Layout.LogicalWidth
+	centred := text.LayoutOptions{PrimaryAlign: text.AlignCenter, SecondaryAlign: text.AlignCenter}
+
+	// title at triple size
+	opts := &text.DrawOptions{LayoutOptions: centred}
+	opts.GeoM.Scale(3, 3)
+	opts.GeoM.Translate(float64(w)/2, float64(m.theme.Layout.MenuTitleY))
+	opts.ColorScale.ScaleWithColor(m.theme.Colors.MenuItemText)
+	text.Draw(screen, m.title, m.theme.Font, opts)
+
+	lineHeight := m.theme.Font.Metrics().HAscent + m.theme.Font.Metrics().HDescent + m.theme.Font.Metrics().HLineGap
+	for i, line := range m.notes {
+		opts := &text.DrawOptions{LayoutOptions: centred}
+		opts.GeoM.Translate(float64(w)/2, float64(m.theme.Layout.MenuTitleY)+3*lineHeight+float64(i)*lineHeight)
+		opts.ColorScale.ScaleWithColor(m.theme.Colors.MenuItemText)
+		text.Draw(screen, line, m.theme.Font, opts)
+	}
+
+	for i, it := range m.items {
+		r := menuItemRect(m.theme.Layout, i)
+		bg := m.theme.Colors.MenuItem
+		if i == m.cursor {
+			bg = m.theme.Colors.MenuItemActive
+		}
+		vector.FillRect(screen, float32(r.Min.X), float32(r.Min.Y), float32(r.Dx()), float32(r.Dy()), bg, false)
+
+		fg := m.theme.Colors.MenuItemText
+		if it.disabled {
+			fg = m.theme.Colors.MenuItemDisabled
+		}
+		opts := &text.DrawOptions{LayoutOptions: centred}
+		opts.GeoM.Translate(float64(r.Min.X+r.Dx()/2), float64(r.Min.Y+r.Dy()/2))
+		opts.ColorScale.ScaleWithColor(fg)
+		text.Draw(screen, it.label, m.theme.Font, opts)
+	}
+
+	if m.decorate != nil {
+		m.decorate(screen)
+	}
+}
@@ -0,0 +1,106 @@
+package ui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/staylor11x/spider-solitaire/internal/daily"
+	"github.com/staylor11x/spider-solitaire/internal/deck"
+	"github.com/staylor11x/spider-solitaire/internal/stats"
+	"github.com/stretchr/testify/assert"
+)
+
+func testMenu(disabled ...bool) *menu {
+	m := &menu{theme: &DefaultTheme}
+	for _, d := range disabled {
+		m.items = append(m.items, menuItem{label: "item", disabled: d})
+	}
+	return m
+}
+
+func TestMenu_MoveSkipsDisabledAndWraps(t *testing.T) {
+	m := testMenu(false, true, false, true)
+	m.cursor = 0
+
+	m.move(1)
+	assert.Equal(t, 2, m.cursor, "skips the disabled item")
+	m.move(1)
+	assert.Equal(t, 0, m.cursor, "wraps past the disabled last item")
+	m.move(-1)
+	assert.Equal(t, 2, m.cursor, "wraps backwards")
+}
+
+func TestMenu_MoveWithNothingEnabled(t *testing.T) {
+	m := testMenu(true, true)
+	m.cursor = -1
+	m.move(1)
+	assert.Equal(t, -1, m.cursor)
+}
+
+func TestMenuItemRect_CentredColumn(t *testing.T) {
+	l := DefaultTheme.Layout
+	first := menuItemRect(l, 0)
+	second := menuItemRect(l, 1)
+
+	assert.Equal(t, l.LogicalWidth-first.Max.X, first.Min.X, "centred horizontally")
+	assert.Equal(t, l.MenuStartY, first.Min.Y)
+	assert.Equal(t, l.MenuItemHeight, first.Dy())
+	assert.Equal(t, first.Max.Y+l.MenuItemGap, second.Min.Y)
+}
+
+func TestMenu_ItemAt(t *testing.T) {
+	m := testMenu(false, true)
+	r0 := menuItemRect(m.theme.Layout, 0)
+	r1 := menuItemRect(m.theme.Layout, 1)
+
+	assert.Equal(t, 0, m.itemAt(r0.Min.X, r0.Min.Y))
+	assert.Equal(t, -1, m.itemAt(r1.Min.X+1, r1.Min.Y+1), "disabled items can't be clicked")
+	assert.Equal(t, -1, m.itemAt(r0.Min.X, r0.Max.Y), "the gap between buttons is not a button")
+	assert.Equal(t, -1, m.itemAt(0, 0))
+}
+
+func TestNextCardBack(t *testing.T) {
+	assert.Equal(t, CardBacks[1], nextCardBack(CardBacks[0]))
+	assert.Equal(t, CardBacks[0], nextCardBack(CardBacks[len(CardBacks)-1]))
+	assert.Equal(t, CardBacks[0], nextCardBack("plaid"))
+	assert.Equal(t, "Card Back: Blue", cardBackLabel("blue"))
+}
+
+func TestNextStatsFilter(t *testing.T) {
+	assert.Equal(t, deck.OneSuit, nextStatsFilter(0))
+	assert.Equal(t, deck.FourSuits, nextStatsFilter(deck.TwoSuits))
+	assert.Equal(t, deck.SuitCount(0), nextStatsFilter(deck.FourSuits), "wraps back to all")
+	assert.Equal(t, "Difficulty: All", statsFilterLabel(0))
+	assert.Equal(t, "Difficulty: 1 Suit", statsFilterLabel(deck.OneSuit))
+	assert.Equal(t, "Difficulty: 4 Suits", statsFilterLabel(deck.FourSuits))
+}
+
+func TestStatsLines(t *testing.T) {
+	assert.Equal(t, []string{"No games played yet."}, statsLines(stats.Summary{}))
+
+	s := stats.Summary{Played: 3, Won: 2, Lost: 1, CurrentStreak: 0, BestStreak: 2, FastestWin: 372 * time.Second, BestScore: 812}
+	assert.Equal(t, []string{
+		"Played 3 - Won 2 (67%) - Lost 1 - Abandoned 0",
+		"Streak 0 - Best streak 2 - Fastest win 6:12 - Best score 812",
+	}, statsLines(s))
+
+	s = stats.Summary{Played: 1, Abandoned: 1}
+	assert.Contains(t, statsLines(s)[1], "Fastest win -", "no win yet")
+	assert.Contains(t, statsLines(s)[1], "Best score -", "no win yet")
+}
+
+func TestNextDailySuits(t *testing.T) {
+	assert.Equal(t, deck.TwoSuits, nextDailySuits(deck.OneSuit))
+	assert.Equal(t, deck.OneSuit, nextDailySuits(deck.FourSuits), "skips all")
+}
+
+func TestDailyLines(t *testing.T) {
+	today := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	l := &daily.Log{}
+	assert.Equal(t, []string{"Streak: 0 days", "Today: not played yet"}, dailyLines(l, deck.OneSuit, today))
+
+	l.Record(daily.Entry{Date: "2026-10-15", Suits: deck.OneSuit, Result: stats.Won})
+	l.Record(daily.Entry{Date: "2026-10-16", Suits: deck.OneSuit, Result: stats.Won, Moves: 140, Score: 760, Duration: 372 * time.Second})
+	assert.Equal(t, []string{"Streak: 2 days", "Today: won - 140 moves - 6:12 - score 760"}, dailyLines(l, deck.OneSuit, today))
+	assert.Equal(t, "Today: not played yet", dailyLines(l, deck.TwoSuits, today)[1])
+}
@@ -0,0 +1,747 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/staylor11x/spider-solitaire/internal/daily"
+	"github.com/staylor11x/spider-solitaire/internal/deck"
+	"github.com/staylor11x/spider-solitaire/internal/game"
+	"github.com/staylor11x/spider-solitaire/internal/logger"
+	"github.com/staylor11x/spider-solitaire/internal/record"
+	"github.com/staylor11x/spider-solitaire/internal/stats"
+	"github.com/staylor11x/spider-solitaire/internal/storage"
+)
+
+// playScene is a game in progress: the tableau, its input handling and overlays
+type playScene struct {
+	app       *Game
+	state     *game.GameState  // Engine state, mutated only in Update
+	view      game.GameViewDTO // Read-only snapshot for rendering
+	atlas     *CardAtlas       // The cards
+	suitCount deck.SuitCount   // Store the difficulty
+	theme     *Theme
+
+	lastErr   string // Ephemeral error text
+	errFrames int    // Frames left to display lastErr
+
+	// Mouse selection state for click-to-move
+	selecting     bool
+	selectedPile  int
+	selectedIndex int
+	showHelp      bool
+	drag          dragState // press-and-drag of the selection
+	focus         keyFocus  // keyboard cursor
+
+	anim       animator  // card movement after each action; purely visual
+	runArrived time.Time // when the newest completed run lands on the foundation
+
+	// Hover state for visual feedback
+	hoveredPile    int  // -1 when no pile is hovered
+	hoveredCardIdx int  // index of hovered card within pile, -1 when none
+	hoveredStock   bool // true when cursor is over stock pile
+
+	// Auto-complete, offered on the empty stock once every card is showing. The line is
+	// searched for only when the player asks, at most once per position.
+	autoOffered bool        // the stock shows the auto-complete button
+	autoLine    []game.Move // nil when the game can't finish itself
+	autoChecked bool        // autoLine is up to date with the position
+
+	// Hint state: suggestions are computed lazily and cycled with M
+	hints    []game.Hint
+	hintIdx  int  // index into hints of the suggestion being shown
+	showHint bool // true while a suggestion is highlighted
+
+	recorder *record.Recorder // nil for resumed games, which can't be replayed from the deal
+	logged   bool             // the game is in the statistics already
+	entry    *stats.Game      // the game's latest statistics entry, replaced if it is logged again
+}
+
+// newPlayScene creates a scene playing the given engine state
+func newPlayScene(app *Game, state *game.GameState) *playScene {
+	view := state.View()
+	logger.Info("NewGame: initial deal (seed=%d, stock=%d, completed=%d, won=%v, lost=%v)", view.Seed, view.StockCount, view.CompletedCount, view.Won, view.Lost)
+
+	p := &playScene{
+		app:            app,
+		atlas:          app.atlas,
+		suitCount:      state.SuitCount,
+		theme:          app.theme,
+		hoveredPile:    -1,
+		hoveredCardIdx: -1,
+	}
+	p.setState(state)
+	return p
+}
+
+// Update runs game logic at 60 FPS
+func (p *playScene) Update() error {
+	// Input always acts on the real game state, so finish any animation first
+	if p.anim.active() && anyInputJustPressed() {
+		p.anim.finish()
+		if now := p.anim.clock(); p.runArrived.After(now) {
+			p.runArrived = now // highlight the run right away
+		}
+	}
+	p.handleKeyboard()
+	p.handleMouse()
+	p.updateHover()
+	p.tickError()
+	p.updateClock()
+	return nil
+}
+
+// leave stops the clock while the game is off screen
+func (p *playScene) leave() {
+	p.clearSelection()
+	p.showHelp = false
+	if !p.state.Paused() {
+		p.state.Pause()
+	}
+}
+
+// over reports whether the game has been won or lost
+func (p *playScene) over() bool {
+	return p.view.Won || p.view.Lost
+}
+
+// updateClock pauses the game clock while the player can't be playing
+// (help open or window unfocused) and refreshes the elapsed time shown in the HUD
+func (p *playScene) updateClock() {
+	away := p.showHelp || !ebiten.IsFocused()
+	if away && !p.state.Paused() {
+		p.state.Pause()
+	} else if !away && p.state.Paused() {
+		p.state.Resume()
+	}
+	p.view.Elapsed = p.state.Elapsed()
+}
+
+// updateHover tracks which pile and card (if any) are under the cursor
+func (p *playScene) updateHover() {
+	mx, my := p.logicalCursor()
+
+	// Check stock pile hover first
+	p.hoveredStock = p.hitTestStock(mx, my)
+
+	pileIdx, cardIdx, ok := p.hitTest(mx, my)
+	if ok {
+		p.hoveredPile = pileIdx
+		p.hoveredCardIdx = cardIdx
+	} else {
+		p.hoveredPile = -1
+		p.hoveredCardIdx = -1
+	}
+}
+
+func (p *playScene) handleKeyboard() {
+	p.handleCursorKeys()
+
+	// D = deal a row
+	if inpututil.IsKeyJustPressed(ebiten.KeyD) {
+		logger.Debug("DealRow: requested")
+		if err := p.state.DealRow(); err != nil {
+			p.setError(err.Error())
+			logger.Error("DealRow: error: %s", err.Error())
+		} else {
+			p.refreshView()
+			p.clearSelection()
+			logger.Info("DealRow: success (stock=%d, completed=%d)", p.view.StockCount, p.view.CompletedCount)
+		}
+	}
+
+	// U or Ctrl+Z = undo last move; Ctrl+Y or Ctrl+Shift+Z = redo it
+	ctrl := ebiten.IsKeyPressed(ebiten.KeyControl) || ebiten.IsKeyPressed(ebiten.KeyMeta)
+	shift := ebiten.IsKeyPressed(ebiten.KeyShift)
+	switch {
+	case inpututil.IsKeyJustPressed(ebiten.KeyU), ctrl && !shift && inpututil.IsKeyJustPressed(ebiten.KeyZ):
+		p.undo()
+	case ctrl && (inpututil.IsKeyJustPressed(ebiten.KeyY) || shift && inpututil.IsKeyJustPressed(ebiten.KeyZ)):
+		p.redo()
+	}
+
+	// A = auto-complete a game that only needs its runs assembled
+	if inpututil.IsKeyJustPressed(ebiten.KeyA) {
+		p.autoComplete()
+	}
+
+	// R = restart this deal from the beginning
+	if inpututil.IsKeyJustPressed(ebiten.KeyR) {
+		logger.Debug("Restart: requested")
+		state, err := p.state.Restart()
+		if err != nil {
+			p.setError(err.Error())
+			logger.Error("Restart: error: %s", err.Error())
+		} else {
+			p.setState(state)
+			p.clearSelection()
+			logger.Info("Restart: success (seed=%d)", p.view.Seed)
+		}
+	}
+
+	// N = new random deal
+	if inpututil.IsKeyJustPressed(ebiten.KeyN) {
+		logger.Debug("NewDeal: requested")
+		state, err := game.DealSeededGame(p.suitCount, deck.RandomSeed())
+		if err != nil {
+			p.setError(err.Error())
+			logger.Error("NewDeal: error: %s", err.Error())
+		} else {
+			p.setState(state)
+			p.clearSelection()
+			logger.Info("NewDeal: success (seed=%d, stock=%d, completed=%d)", p.view.Seed, p.view.StockCount, p.view.CompletedCount)
+		}
+	}
+
+	// Home = rewind to the deal, keeping the moves to redo
+	if inpututil.IsKeyJustPressed(ebiten.KeyHome) {
+		logger.Debug("Rewind: requested")
+		if err := p.state.Rewind(); err != nil {
+			p.setError("No moves to rewind")
+			logger.Warn("Rewind: %s", err.Error())
+		} else {
+			p.refreshView()
+			p.clearSelection()
+			logger.Info("Rewind: back at the deal (seed=%d)", p.view.Seed)
+		}
+	}
+
+	// S = save game
+	if inpututil.IsKeyJustPressed(ebiten.KeyS) {
+		logger.Debug("Save: requested")
+		if err := p.saveGame(); err != nil {
+			p.setError("save failed")
+			logger.Error("Save: error: %s", err.Error())
+		} else {
+			p.app.keepSave = false // the player chose to replace a save that couldn't be resumed
+			p.setStatus("Game saved")
+		}
+	}
+
+	// L = load saved game
+	if inpututil.IsKeyJustPressed(ebiten.KeyL) {
+		logger.Debug("Load: requested")
+		if err := p.loadGame(); err != nil {
+			p.setError("no saved game to load")
+			logger.Error("Load: error: %s", err.Error())
+		} else {
+			p.setStatus("Game loaded")
+		}
+	}
+
+	// E = export the game record
+	if inpututil.IsKeyJustPressed(ebiten.KeyE) {
+		logger.Debug("Export: requested")
+		if path, err := p.exportRecord(); err != nil {
+			p.setError("export failed")
+			logger.Error("Export: error: %s", err.Error())
+		} else {
+			p.setStatus("Record exported to " + path)
+		}
+	}
+
+	// M = show the next move suggestion
+	if inpututil.IsKeyJustPressed(ebiten.KeyM) {
+		p.nextHint()
+	}
+
+	// H = toggle help
+	if inpututil.IsKeyJustPressed(ebiten.KeyH) {
+		p.showHelp = !p.showHelp
+	}
+
+	// ESC = close help, cancel selection, or go back to the menu
+	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+		if p.showHelp {
+			p.showHelp = false
+			logger.Debug("Help overlay closed via ESC key")
+		} else if p.selecting {
+			p.clearSelection()
+			logger.Debug("Selection canceled via ESC key")
+		} else {
+			p.app.showMenu()
+		}
+	}
+}
+
+func (p *playScene) handleMouse() {
+	if p.drag.pressed {
+		p.updateDrag()
+		return
+	}
+	if !inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		return
+	}
+	mx, my := p.logicalCursor()
+	p.focus.shown = false // the mouse takes over from the keyboard cursor
+
+	// Check stock pile click first (when no selection active); once the stock is
+	// gone its place offers auto-complete
+	if !p.selecting && p.hitTestStock(mx, my) && p.autoOffered {
+		p.autoComplete()
+		return
+	}
+	if !p.selecting && p.hitTestStock(mx, my) {
+		logger.Debug("DealRow: requested via stock click")
+		if err := p.state.DealRow(); err != nil {
+			p.setError(err.Error())
+			logger.Error("DealRow: error: %s", err.Error())
+		} else {
+			p.refreshView()
+			p.clearSelection()
+			logger.Info("DealRow: success (stock=%d, completed=%d)", p.view.StockCount, p.view.CompletedCount)
+		}
+		return
+	}
+
+	pileIdx, cardIdx, ok := p.hitTest(mx, my)
+
+	if !p.selecting {
+		// Start selection on a face-up card
+		if ok && p.selectCard(pileIdx, cardIdx) {
+			p.startPress(pileIdx, cardIdx, mx, my)
+		}
+		return
+	}
+	// finish selection, attempt move
+	if ok {
+		p.moveSelection(pileIdx)
+	} else {
+		logger.Debug("Selection canceled by clicking empty space")
+		p.clearSelection()
+	}
+}
+
+// undo takes back the last action
+func (p *playScene) undo() {
+	logger.Debug("Undo: requested")
+	if err := p.state.Undo(); err != nil {
+		p.setError("No moves to undo")
+		logger.Warn("Undo: no history available")
+		return
+	}
+	p.refreshView()
+	p.clearSelection()
+	logger.Info("Undo: reverted to previous state")
+}
+
+// canAutoComplete reports whether the rest of the game can be played out. It searches for
+// the line, so it is only called when the player asks to auto-complete, and the search
+// runs at most once per position.
+func (p *playScene) canAutoComplete() bool {
+	if !p.autoChecked {
+		p.autoLine, _ = p.state.AutoCompleteLine()
+		p.autoChecked = true
+	}
+	return p.autoLine != nil
+}
+
+// autoComplete plays out the rest of the game as one undoable action, using the line
+// already found when the stock offered it
+func (p *playScene) autoComplete() {
+	logger.Debug("AutoComplete: requested")
+	var err error = game.ErrCannotAutoComplete
+	if p.canAutoComplete() {
+		err = p.state.AutoCompleteWith(p.autoLine)
+	}
+	if err != nil {
+		p.setError("Can't finish automatically yet")
+		logger.Warn("AutoComplete: %s", err.Error())
+		return
+	}
+	p.refreshView()
+	p.clearSelection()
+	logger.Info("AutoComplete: finished (moves=%d, completed=%d)", p.view.Moves, p.view.CompletedCount)
+}
+
+// redo plays the last undone action again
+func (p *playScene) redo() {
+	logger.Debug("Redo: requested")
+	if err := p.state.Redo(); err != nil {
+		p.setError("No moves to redo")
+		logger.Warn("Redo: %s", err.Error())
+		return
+	}
+	p.refreshView()
+	p.clearSelection()
+	logger.Info("Redo: replayed (stock=%d, completed=%d)", p.view.StockCount, p.view.CompletedCount)
+}
+
+// selectCard starts a selection from the card at pileIdx/cardIdx, showing why when it can't
+func (p *playScene) selectCard(pileIdx, cardIdx int) bool {
+	// Check for empty pile first
+	if len(p.view.Tableau[pileIdx].Cards) == 0 {
+		p.setError("cannot select from empty pile")
+		logger.Warn("Select: empty pile (pile=%d)", pileIdx)
+		return false
+	}
+	if cardIdx >= len(p.view.Tableau[pileIdx].Cards) {
+		p.setError("invalid card")
+		logger.Warn("Select: invalid card (pile=%d, idx=%d)", pileIdx, cardIdx)
+		return false
+	}
+	if !p.view.Tableau[pileIdx].Cards[cardIdx].FaceUp {
+		p.setError("select a face-up card")
+		logger.Warn("Select: not face-up (pile=%d, idx=%d)", pileIdx, cardIdx)
+		return false
+	}
+	p.selecting = true
+	p.selectedPile = pileIdx
+	p.selectedIndex = cardIdx
+	logger.Debug("Select: start (pile=%d, idx=%d)", pileIdx, cardIdx)
+	return true
+}
+
+// moveSelection moves the selected cards onto pileIdx and ends the selection
+func (p *playScene) moveSelection(pileIdx int) {
+	logger.Debug("Move: attempt %d:%d -> %d", p.selectedPile, p.selectedIndex, pileIdx)
+	if err := p.performMove(p.selectedPile, p.selectedIndex, pileIdx); err != nil {
+		p.setError(err.Error())
+		logger.Error("Move: error: %s", err.Error())
+	} else {
+		p.refreshView()
+		logger.Info("Move: success %d:%d -> %d (completed=%d)", p.selectedPile, p.selectedIndex, pileIdx, p.view.CompletedCount)
+	}
+	p.clearSelection()
+}
+
+// arrivalFlash fades from 1 to 0 over Timing.Arrival once the newest run reaches the foundation
+func (p *playScene) arrivalFlash() float64 {
+	since := p.anim.clock().Sub(p.runArrived)
+	if p.runArrived.IsZero() || since < 0 || since >= p.theme.Timing.Arrival {
+		return 0
+	}
+	return 1 - float64(since)/float64(p.theme.Timing.Arrival)
+}
+
+// anyInputJustPressed reports whether a key or mouse button went down this frame
+func anyInputJustPressed() bool {
+	return len(inpututil.AppendJustPressedKeys(nil)) > 0 ||
+		inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) ||
+		inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonRight)
+}
+
+// logicalCursor maps the OS/window cursor to logical coordinates
+// Ebiten returns cursor positions in Layout-space, so no manual scaling is needed!
+func (p *playScene) logicalCursor() (lx, ly int) {
+	return ebiten.CursorPosition()
+}
+
+// hitTest finds the top-most card under the cursor, returning pile and card indices
+func (p *playScene) hitTest(mx, my int) (pileIdx, cardIdx int, ok bool) {
+	for i, pile := range p.view.Tableau {
+		x := p.theme.Layout.TableauStartX + i*p.theme.Layout.PileSpacing
+		// quick horizontal reject
+		if mx < x || mx >= x+p.theme.Layout.CardWidth {
+			continue
+		}
+		y := p.theme.Layout.TableauStartY
+		// If the pile is empty, treat clicks within the column's base area as valid
+		if len(pile.Cards) == 0 {
+			if my >= y && my < y+p.theme.Layout.CardHeight {
+				return i, 0, true
+			}
+			// no cards and click outside base area: continue searching
+			continue
+		}
+
+		layout := computeTableauPileLayout(p.theme, len(pile.Cards))
+
+		// Cards overlap; check top-most first
+		for j := len(pile.Cards) - 1; j >= 0; j-- {
+			cy := layout.CardY[j]
+			if mx >= x && mx < x+p.theme.Layout.CardWidth && my >= cy && my < cy+p.theme.Layout.CardHeight {
+				return i, j, true
+			}
+		}
+		// if clicking below all cards but within column, treat as click on topmost card area
+		// Allow a small margin below the bottom card for easier targeting.
+		// Tie this to computed gap so hit-testing matches compressed rendering.
+		if len(pile.Cards) > 0 {
+			topY := layout.CardY[len(pile.Cards)-1]
+			bottomY := topY + p.theme.Layout.CardHeight
+			clickMarginBelow := max(layout.Gap, 8)
+			if mx >= x && mx < x+p.theme.Layout.CardWidth && my >= bottomY && my < bottomY+clickMarginBelow {
+				return i, len(pile.Cards) - 1, true
+			}
+		}
+	}
+	return 0, 0, false
+}
+
+func (p *playScene) hitTestStock(mx, my int) bool {
+	stockX := p.theme.Layout.StockX
+	stockY := p.theme.Layout.StockY
+	return mx >= stockX && mx < stockX+p.theme.Layout.CardWidth &&
+		my >= stockY && my < stockY+p.theme.Layout.CardHeight
+}
+
+func (p *playScene) tickError() {
+
+	// tickle down the error overlay timer
+	if p.errFrames > 0 {
+		p.errFrames--
+		if p.errFrames == 0 {
+			p.lastErr = ""
+		}
+	}
+}
+
+func (p *playScene) setError(msg string) {
+	p.lastErr = msg
+	p.errFrames = p.theme.Layout.ErrorDisplayDuration
+	logger.Warn("Error: %s", msg)
+}
+
+// refreshView re-snapshots the engine after any state change and animates the change.
+// Hints describe the old position, so they are dropped.
+func (p *playScene) refreshView() {
+	before := p.view
+	p.view = p.state.View()
+	p.anim.play(planAnimation(p.theme, before, p.view, p.state.LastEvents()))
+	if p.view.CompletedCount > before.CompletedCount && p.view.Seed == before.Seed {
+		p.runArrived = p.anim.clock().Add(p.anim.current.end)
+	}
+	p.hints = nil
+	p.showHint = false
+	p.autoOffered = autoCompleteOffered(p.view)
+	p.autoLine, p.autoChecked = nil, false // searched again when next asked
+	p.clampFocus()
+	// undone out of a win or loss, the game goes on and is logged again when it ends
+	if p.logged && !p.over() {
+		p.logged = false
+	}
+	if p.over() && !p.logged {
+		p.logStats()
+	}
+}
+
+// autoCompleteOffered reports whether the stock should offer auto-complete: the game is on,
+// the stock is gone and every card is face up. It only looks at the view, so it is cheap
+// enough to work out after every change; whether a winning line exists is left to the click.
+func autoCompleteOffered(v game.GameViewDTO) bool {
+	if v.Won || v.Lost || v.StockCount > 0 {
+		return false
+	}
+	for _, pile := range v.Tableau {
+		for _, c := range pile.Cards {
+			if !c.FaceUp {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// nextHint highlights the next ranked suggestion, computing them on first use
+func (p *playScene) nextHint() {
+	if p.hints == nil {
+		p.hints = p.state.Hints()
+		p.hintIdx = -1
+	}
+	if len(p.hints) == 0 {
+		p.showHint = false
+		p.setStatus("No moves available - position is stuck")
+		logger.Info("Hint: none available")
+		return
+	}
+
+	p.clearSelection()
+	p.hintIdx = (p.hintIdx + 1) % len(p.hints)
+	p.showHint = true
+	h := p.hints[p.hintIdx]
+	p.setStatus(fmt.Sprintf("Hint %d/%d: %s", p.hintIdx+1, len(p.hints), h.Reason))
+	logger.Debug("Hint: %s (score=%d)", h.Move, h.Score)
+}
+
+// setStatus shows an informational message in the same pill as errors
+func (p *playScene) setStatus(msg string) {
+	p.lastErr = msg
+	p.errFrames = p.theme.Layout.ErrorDisplayDuration
+	logger.Info("Status: %s", msg)
+}
+
+// saveGame writes the current game to the default save location
+func (p *playScene) saveGame() error {
+	path, err := storage.SavePath()
+	if err != nil {
+		return err
+	}
+	if err := storage.SaveGame(path, p.state); err != nil {
+		return err
+	}
+	logger.Info("Save: wrote %s", path)
+	return nil
+}
+
+// loadGame replaces the current game with the one at the default save location
+func (p *playScene) loadGame() error {
+	path, err := storage.SavePath()
+	if err != nil {
+		return err
+	}
+	state, err := storage.LoadGame(path)
+	if err != nil {
+		return err
+	}
+	p.setState(state)
+	p.suitCount = state.SuitCount
+	p.clearSelection()
+	logger.Info("Load: read %s (seed=%d)", path, p.view.Seed)
+	return nil
+}
+
+// setState swaps in a new engine game, subscribes to its events and starts recording it
+func (p *playScene) setState(state *game.GameState) {
+	if p.state != nil {
+		p.abandon()
+	}
+	if p.recorder != nil {
+		p.recorder.Stop()
+	}
+	recorder, err := record.NewRecorder(state)
+	if err != nil {
+		logger.Debug("Record: not recording: %s", err.Error())
+	}
+	p.recorder = recorder
+
+	state.Subscribe(logEvent)
+	p.state = state
+	// a game already won or lost was logged when it ended
+	p.entry = nil
+	if e, ok := stats.Finished(state); ok {
+		p.entry = &e
+	}
+	p.logged = p.entry != nil
+	p.refreshView()
+}
+
+// abandon logs the game as abandoned if it is under way and wasn't won or lost;
+// call it before the game is replaced
+func (p *playScene) abandon() {
+	if !p.logged && stats.Started(p.state) {
+		p.logStats()
+	}
+}
+
+// logStats adds the game to the player's statistics, and to the daily challenge results
+// when it is a daily deal. A game logged before, and since undone out of its result,
+// replaces its earlier entry.
+func (p *playScene) logStats() {
+	p.logged = true
+	now := time.Now()
+	entry := stats.FromGame(p.state, now)
+	path, err := storage.StatsPath()
+	if err == nil && p.entry != nil {
+		err = storage.ReplaceStats(path, *p.entry, entry)
+	} else if err == nil {
+		err = storage.AddStats(path, entry)
+	}
+	p.entry = &entry
+	if err != nil {
+		logger.Error("Stats: error: %s", err.Error())
+	} else {
+		logger.Info("Stats: logged %s %d-suit game (seed=%d, score=%d)", entry.Result, entry.Suits, entry.Seed, entry.Score)
+	}
+
+	day, ok := daily.DateOf(p.state, now)
+	if !ok {
+		return
+	}
+	if path, err = storage.DailyPath(); err == nil {
+		err = storage.AddDaily(path, daily.FromGame(p.state, day))
+	}
+	if err != nil {
+		logger.Error("Daily: error: %s", err.Error())
+		return
+	}
+	logger.Info("Daily: recorded %s challenge for %s", entry.Result, daily.Key(day))
+}
+
+// exportRecord writes the record of the current game next to the save file
+func (p *playScene) exportRecord() (string, error) {
+	if p.recorder == nil {
+		return "", record.ErrGameInProgress
+	}
+	path, err := storage.RecordPath(p.state.Seed)
+	if err != nil {
+		return "", err
+	}
+	if err := storage.SaveRecord(path, p.recorder.Record()); err != nil {
+		return "", err
+	}
+	logger.Info("Export: wrote %s", path)
+	return path, nil
+}
+
+// logEvent traces engine events so side effects like reveals and completed runs show up in the log
+func logEvent(e game.Event) {
+	logger.Debug("Event: %s", e)
+}
+
+func (p *playScene) clearSelection() {
+	p.selecting = false
+	p.selectedPile = -1
+	p.selectedIndex = -1
+	p.drag = dragState{}
+}
+
+// Draw renders the current frame to the screen
+func (p *playScene) Draw(screen *ebiten.Image) {
+	screen.Fill(p.theme.Colors.Background)
+	selectedPile, selectedIndex := -1, -1
+	if p.selecting {
+		selectedPile, selectedIndex = p.selectedPile, p.selectedIndex
+	}
+	// Under the tableau, so tall piles stay readable.
+	// Completed runs still flying in are drawn by the animation.
+	landed := p.view.CompletedSuits[:max(len(p.view.CompletedSuits)-p.anim.runsInFlight(), 0)]
+	drawFoundation(screen, landed, p.atlas, p.theme, p.arrivalFlash())
+
+	focusPile, focusIdx := p.focusTarget()
+	drawTableau(screen, p.view, p.atlas, p.theme, selectedPile, selectedIndex, p.hoveredPile, p.hoveredCardIdx, focusPile, focusIdx, p.anim.hiddenFrom(p.view))
+
+	// Draw stock pile visual with hover and depletion
+	drawStockPile(screen, p.view.StockCount, p.atlas, p.theme, p.hoveredStock, p.autoOffered)
+
+	p.anim.draw(screen, p.atlas, p.theme)
+
+	if p.selecting && p.drag.active {
+		drawDropTargets(screen, p.view, p.drag.targets, p.theme)
+		dx, dy := p.dragOffset()
+		drawSelectionOverlay(screen, p.view, p.selectedPile, p.selectedIndex, dx, dy, p.atlas, p.theme)
+	} else if p.selecting {
+		drawSelectionOverlay(screen, p.view, p.selectedPile, p.selectedIndex, 0, 0, p.atlas, p.theme)
+	}
+
+	if p.showHint && p.hintIdx >= 0 && p.hintIdx < len(p.hints) {
+		drawHint(screen, p.view, p.hints[p.hintIdx].Move, p.theme)
+	}
+
+	drawStats(screen, p.view, p.theme)
+
+	if p.lastErr != "" && p.errFrames > 0 {
+		drawError(screen, p.lastErr, p.theme)
+	}
+
+	// let the last cards land before announcing the result
+	switch {
+	case p.anim.active():
+	case p.view.Won:
+		drawWinLossOverlay(screen, "You Win!", p.theme)
+	case p.view.Lost:
+		drawWinLossOverlay(screen, "Game Over :(", p.theme)
+	}
+
+	if p.showHelp {
+		drawHelpOverlay(screen, p.theme)
+	}
+
+}
+
+// performMove executes the engine move via MoveSequence
+func (p *playScene) performMove(srcPile, startIdx, dstPile int) error {
+	return p.state.MoveSequence(srcPile, startIdx, dstPile)
+}
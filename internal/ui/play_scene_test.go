@@ -0,0 +1,25 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/staylor11x/spider-solitaire/internal/deck"
+	"github.com/staylor11x/spider-solitaire/internal/game"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRefreshView_OffersAutoCompleteWithoutSearching(t *testing.T) {
+	p := testPlayScene(t)
+	assert.False(t, p.autoOffered, "a fresh deal has stock and hidden cards")
+
+	state := &game.GameState{}
+	state.Tableau.Piles[0].AddCard(deck.Card{Suit: deck.Spades, Rank: deck.King}, true)
+	p.state = state
+	p.refreshView()
+	assert.True(t, p.autoOffered, "no stock and every card showing")
+	assert.False(t, p.autoChecked, "the line isn't searched for until asked")
+
+	over := p.view
+	over.Lost = true
+	assert.False(t, autoCompleteOffered(over), "a finished game offers nothing")
+}
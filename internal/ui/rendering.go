@@ -2,6 +2,7 @@ package ui
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/text/v2"
@@ -10,8 +11,10 @@ import (
 	"github.com/staylor11x/spider-solitaire/internal/game"
 )
 
-// drawTableau renders all 10 piles from the view snapshot
-func drawTableau(screen *ebiten.Image, view game.GameViewDTO, atlas *CardAtlas, theme *Theme, selectedPile, selectedIndex, hoveredPile, hoveredCardIdx int) {
+// drawTableau renders all 10 piles from the view snapshot.
+// focusPile/focusIdx place the keyboard cursor (-1 for none), which is outlined rather than shaded like hover.
+// hiddenFrom (nil for none) gives per pile the index from which cards are left out because they are still animating.
+func drawTableau(screen *ebiten.Image, view game.GameViewDTO, atlas *CardAtlas, theme *Theme, selectedPile, selectedIndex, hoveredPile, hoveredCardIdx, focusPile, focusIdx int, hiddenFrom []int) {
 	// When a selection is active, suppress hover overlays to avoid visual noise
 	selectionActive := selectedPile >= 0 && selectedIndex >= 0
 
@@ -24,8 +27,29 @@ func drawTableau(screen *ebiten.Image, view game.GameViewDTO, atlas *CardAtlas,
 		if isHovered && !selectionActive {
 			hvdCardIdx = hoveredCardIdx
 		}
+		if hiddenFrom != nil && hiddenFrom[i] < len(pile.Cards) {
+			pile.Cards = pile.Cards[:hiddenFrom[i]]
+		}
 		drawPile(screen, pile, x, y, atlas, theme, isSelected, selectedIndex, hvdCardIdx)
+		if i == focusPile {
+			drawFocus(screen, len(view.Tableau[i].Cards), focusIdx, x, theme)
+		}
+	}
+}
+
+// drawFocus outlines the keyboard cursor around cards focusIdx to the top of a pile of n cards,
+// or around the placeholder of an empty pile. The outline sits just outside the cards so it
+// stays visible next to hover, hint and selection highlights.
+func drawFocus(screen *ebiten.Image, n, focusIdx, x int, theme *Theme) {
+	border := float32(theme.Layout.FocusBorderPx)
+	top := float32(theme.Layout.TableauStartY)
+	bottom := top + float32(theme.Layout.CardHeight)
+	if n > 0 {
+		layout := computeTableauPileLayout(theme, n)
+		top = float32(layout.CardY[min(max(focusIdx, 0), n-1)])
+		bottom = float32(layout.CardY[n-1] + theme.Layout.CardHeight)
 	}
+	vector.StrokeRect(screen, float32(x)-border, top-border, float32(theme.Layout.CardWidth)+2*border, bottom-top+2*border, border, theme.Colors.FocusBorder, false)
 }
 
 // drawPile renders a single pile at the given position
@@ -100,6 +124,13 @@ func computeMovableHoverEnd(cards []game.CardDTO, startIdx int) int {
 
 // drawCard renders a single card at the given position
 func drawCard(screen *ebiten.Image, card game.CardDTO, x, y int, atlas *CardAtlas, theme *Theme) {
+	drawCardScaled(screen, card, float64(x), float64(y), 1, atlas, theme)
+}
+
+// drawCardScaled renders a card squeezed horizontally by scaleX about its centre, as when it is flipping over
+func drawCardScaled(screen *ebiten.Image, card game.CardDTO, x, y, scaleX float64, atlas *CardAtlas, theme *Theme) {
+	cw := float64(theme.Layout.CardWidth)
+	x += cw * (1 - scaleX) / 2
 
 	if atlas != nil {
 		var img *ebiten.Image
@@ -115,10 +146,10 @@ func drawCard(screen *ebiten.Image, card game.CardDTO, x, y int, atlas *CardAtla
 			h := img.Bounds().Dy()
 			opts := &ebiten.DrawImageOptions{}
 			// Scale to logical card size if asset size differs
-			sx := float64(theme.Layout.CardWidth) / float64(w)
+			sx := cw * scaleX / float64(w)
 			sy := float64(theme.Layout.CardHeight) / float64(h)
 			opts.GeoM.Scale(sx, sy)
-			opts.GeoM.Translate(float64(x), float64(y))
+			opts.GeoM.Translate(x, y)
 			screen.DrawImage(img, opts)
 			return
 		}
@@ -131,7 +162,7 @@ func drawCard(screen *ebiten.Image, card game.CardDTO, x, y int, atlas *CardAtla
 	}
 
 	// card rectangle
-	vector.FillRect(screen, float32(x), float32(y), float32(theme.Layout.CardWidth), float32(theme.Layout.CardHeight), bgColor, false)
+	vector.FillRect(screen, float32(x), float32(y), float32(cw*scaleX), float32(theme.Layout.CardHeight), bgColor, false)
 
 	// card text
 	var cardText string
@@ -148,7 +179,7 @@ func drawCard(screen *ebiten.Image, card game.CardDTO, x, y int, atlas *CardAtla
 			SecondaryAlign: text.AlignCenter,
 		},
 	}
-	drawOpts.GeoM.Translate(float64(x+theme.Layout.CardWidth/2), float64(y+theme.Layout.CardHeight/2))
+	drawOpts.GeoM.Translate(x+cw*scaleX/2, y+float64(theme.Layout.CardHeight)/2)
 	drawOpts.ColorScale.ScaleWithColor(theme.Colors.CardText)
 
 	text.Draw(screen, cardText, theme.Font, drawOpts)
@@ -160,10 +191,10 @@ func formatCard(card game.CardDTO) string {
 	return fmt.Sprintf("%s%s", c.RankName(), c.SuitName())
 }
 
-// drawStats renders stock and completed counts at the top-left
+// drawStats renders the deal number, score, moves, time, stock and completed counts at the top-left
 func drawStats(screen *ebiten.Image, view game.GameViewDTO, theme *Theme) {
-	stats := fmt.Sprintf("Stock: %d | Completed: %d | Won: %v | Lost: %v",
-		view.StockCount, view.CompletedCount, view.Won, view.Lost)
+	stats := fmt.Sprintf("Deal: %d (%d-suit) | Score: %d | Moves: %d (deals %d, undos %d) | Time: %s | Stock: %d | Completed: %d | Won: %v | Lost: %v",
+		view.Seed, view.SuitCount, view.Score, view.Moves, view.Deals, view.Undos, formatElapsed(view.Elapsed), view.StockCount, view.CompletedCount, view.Won, view.Lost)
 
 	drawOpts := &text.DrawOptions{}
 	drawOpts.GeoM.Translate(float64(theme.Layout.StatsX), float64(theme.Layout.StatsY))
@@ -172,6 +203,12 @@ func drawStats(screen *ebiten.Image, view game.GameViewDTO, theme *Theme) {
 	text.Draw(screen, stats, theme.Font, drawOpts)
 }
 
+// formatElapsed renders play time as m:ss
+func formatElapsed(d time.Duration) string {
+	secs := int(d.Seconds())
+	return fmt.Sprintf("%d:%02d", secs/60, secs%60)
+}
+
 // drawError shows an ephemeral error message at the top-right (centered in pill)
 func drawError(screen *ebiten.Image, msg string, theme *Theme) {
 
@@ -203,7 +240,8 @@ func drawError(screen *ebiten.Image, msg string, theme *Theme) {
 
 // drawSelectionOverlay highlights the selected suffix (from selectedIndex to top) on a pile.
 // Cards are lifted 8 pixels upward and outlined with a goldenrod border for visual feedback.
-func drawSelectionOverlay(screen *ebiten.Image, view game.GameViewDTO, pileIdx, selectedIndex int, atlas *CardAtlas, theme *Theme) {
+// dx, dy shift the cards from their place in the pile, so a dragged sequence follows the cursor.
+func drawSelectionOverlay(screen *ebiten.Image, view game.GameViewDTO, pileIdx, selectedIndex, dx, dy int, atlas *CardAtlas, theme *Theme) {
 	if pileIdx < 0 || pileIdx >= len(view.Tableau) {
 		return
 	}
@@ -211,11 +249,11 @@ func drawSelectionOverlay(screen *ebiten.Image, view game.GameViewDTO, pileIdx,
 	if selectedIndex < 0 || selectedIndex >= len(pile.Cards) {
 		return
 	}
-	x := theme.Layout.TableauStartX + pileIdx*theme.Layout.PileSpacing
+	x := theme.Layout.TableauStartX + pileIdx*theme.Layout.PileSpacing + dx
 	layout := computeTableauPileLayout(theme, len(pile.Cards))
 
 	for i := selectedIndex; i < len(pile.Cards); i++ {
-		cy := layout.CardY[i] - theme.Layout.SelectionLiftPx
+		cy := layout.CardY[i] - theme.Layout.SelectionLiftPx + dy
 		// Redraw the card at the lifted position
 		drawCard(screen, pile.Cards[i], x, cy, atlas, theme)
 		// Gold tint overlay
@@ -225,6 +263,57 @@ func drawSelectionOverlay(screen *ebiten.Image, view game.GameViewDTO, pileIdx,
 	}
 }
 
+// drawDropTargets outlines the top card (or empty placeholder) of each pile a dragged sequence can be dropped on
+func drawDropTargets(screen *ebiten.Image, view game.GameViewDTO, targets []int, theme *Theme) {
+	w, h := float32(theme.Layout.CardWidth), float32(theme.Layout.CardHeight)
+	for _, i := range targets {
+		if i < 0 || i >= len(view.Tableau) {
+			continue
+		}
+		x := float32(theme.Layout.TableauStartX + i*theme.Layout.PileSpacing)
+		y := float32(theme.Layout.TableauStartY)
+		if n := len(view.Tableau[i].Cards); n > 0 {
+			y = float32(computeTableauPileLayout(theme, n).CardY[n-1])
+		}
+		vector.FillRect(screen, x, y, w, h, theme.Colors.DropTargetOverlay, false)
+		vector.StrokeRect(screen, x, y, w, h, float32(theme.Layout.HintBorderPx), theme.Colors.DropTarget, false)
+	}
+}
+
+// drawHint outlines a suggested move: the source cards and the destination pile,
+// or the stock pile when the suggestion is to deal.
+func drawHint(screen *ebiten.Image, view game.GameViewDTO, m game.Move, theme *Theme) {
+	w, h := float32(theme.Layout.CardWidth), float32(theme.Layout.CardHeight)
+	border := float32(theme.Layout.HintBorderPx)
+
+	if m.Kind == game.MoveDeal {
+		vector.StrokeRect(screen, float32(theme.Layout.StockX), float32(theme.Layout.StockY), w, h, border, theme.Colors.HintDestination, false)
+		return
+	}
+	if m.Src < 0 || m.Src >= len(view.Tableau) || m.Dst < 0 || m.Dst >= len(view.Tableau) {
+		return
+	}
+
+	// source: every card from the start of the sequence to the top
+	src := view.Tableau[m.Src]
+	srcX := float32(theme.Layout.TableauStartX + m.Src*theme.Layout.PileSpacing)
+	srcLayout := computeTableauPileLayout(theme, len(src.Cards))
+	for i := m.Start; i < len(src.Cards); i++ {
+		y := float32(srcLayout.CardY[i])
+		vector.FillRect(screen, srcX, y, w, h, theme.Colors.HintSourceOverlay, false)
+		vector.StrokeRect(screen, srcX, y, w, h, border, theme.Colors.HintSource, false)
+	}
+
+	// destination: the top card, or the empty pile placeholder
+	dst := view.Tableau[m.Dst]
+	dstX := float32(theme.Layout.TableauStartX + m.Dst*theme.Layout.PileSpacing)
+	dstY := float32(theme.Layout.TableauStartY)
+	if n := len(dst.Cards); n > 0 {
+		dstY = float32(computeTableauPileLayout(theme, n).CardY[n-1])
+	}
+	vector.StrokeRect(screen, dstX, dstY, w, h, border, theme.Colors.HintDestination, false)
+}
+
 func drawEmptyPilePlaceholder(screen *ebiten.Image, x, y int, theme *Theme) {
 	// Faint fill and border for visibility on table felt
 	fill := theme.Colors.PlaceholderBG
@@ -235,6 +324,25 @@ func drawEmptyPilePlaceholder(screen *ebiten.Image, x, y int, theme *Theme) {
 
 }
 
+// drawAutoButton fills the empty stock with a button that auto-completes the game
+func drawAutoButton(screen *ebiten.Image, x, y int, theme *Theme, isHovered bool) {
+	w, h := float32(theme.Layout.CardWidth), float32(theme.Layout.CardHeight)
+	bg := theme.Colors.MenuItem
+	if isHovered {
+		bg = theme.Colors.MenuItemActive
+	}
+	vector.FillRect(screen, float32(x), float32(y), w, h, bg, false)
+
+	opts := &text.DrawOptions{LayoutOptions: text.LayoutOptions{
+		PrimaryAlign:   text.AlignCenter,
+		SecondaryAlign: text.AlignCenter,
+		LineSpacing:    theme.Font.Metrics().HAscent + theme.Font.Metrics().HDescent,
+	}}
+	opts.GeoM.Translate(float64(x)+float64(w)/2, float64(y)+float64(h)/2)
+	opts.ColorScale.ScaleWithColor(theme.Colors.MenuItemText)
+	text.Draw(screen, "Auto\nFinish\n[A]", theme.Font, opts)
+}
+
 // drawWinLossOverlay darkens the background and renders a centered message
 func drawWinLossOverlay(screen *ebiten.Image, msg string, theme *Theme) {
 	b := screen.Bounds()
@@ -264,12 +372,22 @@ func drawHelpOverlay(screen *ebiten.Image, theme *Theme) {
 	helpLines := []string{
 		"Controls",
 		"",
-		"Click - Select/Move Cards",
+		"Click or Drag - Select/Move Cards",
+		"[Arrows] - Move Cursor, [0-9] - Jump to Pile",
+		"[Enter]/[Space] - Pick Up / Drop at Cursor",
 		"[D] - Deal Row",
-		"[U] - Undo Move",
-		"[R] - Reset Game",
+		"[U] or [Ctrl+Z] - Undo Move",
+		"[Ctrl+Y] or [Ctrl+Shift+Z] - Redo Move",
+		"[M] - Show Hint (press again for the next)",
+		"[A] - Auto-Finish (stock empty, all cards face up)",
+		"[Home] - Rewind to Deal (redo to step forward)",
+		"[R] - Restart This Deal",
+		"[N] - New Deal",
+		"[S] - Save Game",
+		"[L] - Load Saved Game",
+		"[E] - Export Game Record",
 		"[H] - Toggle Help",
-		"[ESC] - Cancel Selection / Close Help",
+		"[ESC] - Cancel Selection / Close Help / Menu",
 		"",
 		"Press [H] to close",
 	}
@@ -292,14 +410,17 @@ func drawHelpOverlay(screen *ebiten.Image, theme *Theme) {
 }
 
 // drawStockPile renders the stock pile visual in the bottom-right corner
-func drawStockPile(screen *ebiten.Image, stockCount int, atlas *CardAtlas, theme *Theme, isHovered bool) {
-	// Position: bottom-right corner with 20px margin
-	stockX := 1120
-	stockY := 580
+func drawStockPile(screen *ebiten.Image, stockCount int, atlas *CardAtlas, theme *Theme, isHovered, canAuto bool) {
+	// Position: bottom-right corner
+	stockX := theme.Layout.StockX
+	stockY := theme.Layout.StockY
 
-	// If stock is empty, show placeholder
+	// If stock is empty, show placeholder, or the auto-complete button when the game can be finished
 	if stockCount == 0 {
 		drawEmptyPilePlaceholder(screen, stockX, stockY, theme)
+		if canAuto {
+			drawAutoButton(screen, stockX, stockY, theme, isHovered)
+		}
 		return
 	}
 
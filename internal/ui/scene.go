@@ -0,0 +1,253 @@
+package ui
+
+import (
+	"errors"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/staylor11x/spider-solitaire/internal/assets"
+	"github.com/staylor11x/spider-solitaire/internal/daily"
+	"github.com/staylor11x/spider-solitaire/internal/deck"
+	"github.com/staylor11x/spider-solitaire/internal/game"
+	"github.com/staylor11x/spider-solitaire/internal/logger"
+	"github.com/staylor11x/spider-solitaire/internal/storage"
+)
+
+// Scene is one screen of the app (main menu, a game in progress, settings, ...).
+// Only the current scene is updated and drawn.
+type Scene interface {
+	Update() error
+	Draw(screen *ebiten.Image)
+}
+
+// Game implements the ebiten.Game interface for Spider Solitaire. It owns what every
+// screen shares (cards, theme, settings) and hands each frame to the current scene.
+type Game struct {
+	atlas    *CardAtlas
+	theme    *Theme
+	settings storage.Settings
+	scene    Scene
+	play     *playScene // the game in progress, kept while other scenes are shown; nil until one is dealt
+
+	saveProblem string // why the saved game couldn't be resumed, shown once on the main menu
+	keepSave    bool   // the save couldn't be read, so autosave leaves it for the player
+}
+
+func newApp() *Game {
+	atlas, err := NewCardAtlas(assets.Files)
+	if err != nil {
+		panic(err) // TODO: handle this gracefully too
+	}
+	g := &Game{atlas: atlas, theme: &DefaultTheme}
+	g.loadSettings()
+	return g
+}
+
+// NewMenu creates an Ebiten game instance that opens on the main menu
+func NewMenu() *Game {
+	g := newApp()
+	g.showMenu()
+	return g
+}
+
+// NewGame create a new Ebiten game instance playing the deal identified by seed
+func NewGame(suitCount deck.SuitCount, seed uint64) *Game {
+	state, err := game.DealSeededGame(suitCount, seed)
+	if err != nil {
+		panic(err) // TODO: Handle this error gracefully
+	}
+	return NewGameFromState(state)
+}
+
+// NewContinued creates an Ebiten game instance that resumes the saved game, or deals the
+// game identified by seed when there is no save. A save that can't be resumed opens the
+// main menu saying why, and is left in place.
+func NewContinued(suitCount deck.SuitCount, seed uint64) *Game {
+	path, err := storage.SavePath()
+	if err != nil || !storage.HasSave(path) {
+		return NewGame(suitCount, seed)
+	}
+	g := newApp()
+	g.resumeSave(path)
+	return g
+}
+
+// NewGameFromState creates an Ebiten game instance that resumes an existing engine state
+func NewGameFromState(state *game.GameState) *Game {
+	g := newApp()
+	g.startGame(state)
+	return g
+}
+
+// Update runs the current scene at 60 FPS
+func (g *Game) Update() error {
+	// Autosave when the window is closed so the game can be resumed next time
+	if ebiten.IsWindowBeingClosed() {
+		return g.quit()
+	}
+	return g.scene.Update()
+}
+
+// Draw renders the current scene
+func (g *Game) Draw(screen *ebiten.Image) {
+	g.scene.Draw(screen)
+}
+
+// Layout returns the logical screen dimensions (fixed).
+func (g *Game) Layout(outsideWidth, outsideHeight int) (screenWidth, screenHeight int) {
+	return g.theme.Layout.LogicalWidth, g.theme.Layout.LogicalHeight
+}
+
+// switchTo makes s the current scene, stopping the game clock if play is left
+func (g *Game) switchTo(s Scene) {
+	if g.play != nil && g.scene == Scene(g.play) && s != Scene(g.play) {
+		g.play.leave()
+	}
+	g.scene = s
+}
+
+func (g *Game) showMenu() {
+	g.switchTo(newMainMenu(g))
+}
+
+// startGame plays state, replacing any game in progress
+func (g *Game) startGame(state *game.GameState) {
+	if g.play != nil {
+		g.play.abandon()
+	}
+	g.play = newPlayScene(g, state)
+	g.scene = g.play
+}
+
+// newGame deals a random game at the given difficulty
+func (g *Game) newGame(suits deck.SuitCount) error {
+	state, err := game.DealSeededGame(suits, deck.RandomSeed())
+	if err != nil {
+		return err
+	}
+	logger.Info("Menu: new %d-suit game", suits)
+	g.startGame(state)
+	return nil
+}
+
+// dailyGame plays today's daily challenge at the given difficulty, going back to it
+// when it is the game in progress
+func (g *Game) dailyGame(suits deck.SuitCount) error {
+	now := time.Now()
+	if g.play != nil && !g.play.over() && g.play.state.SuitCount == suits && g.play.state.Seed == daily.Seed(now, suits) {
+		g.switchTo(g.play)
+		return nil
+	}
+	state, err := daily.Deal(now, suits)
+	if err != nil {
+		return err
+	}
+	logger.Info("Menu: daily %d-suit challenge for %s", suits, daily.Key(now))
+	g.startGame(state)
+	return nil
+}
+
+// canContinue reports whether there is an unfinished game in memory or a save to resume
+func (g *Game) canContinue() bool {
+	if g.play != nil && !g.play.over() {
+		return true
+	}
+	path, err := storage.SavePath()
+	return err == nil && storage.HasSave(path)
+}
+
+// resume returns to the game in progress, or loads the saved one
+func (g *Game) resume() error {
+	if g.play != nil && !g.play.over() {
+		g.switchTo(g.play)
+		return nil
+	}
+	path, err := storage.SavePath()
+	if err != nil {
+		return err
+	}
+	g.resumeSave(path)
+	return nil
+}
+
+// resumeSave plays the game saved at path. When it can't, the main menu says why, and a
+// save that couldn't be read is kept from the autosave so the player doesn't lose it.
+func (g *Game) resumeSave(path string) {
+	state, err := storage.ResumeGame(path)
+	if err != nil {
+		logger.Error("Continue: error: %s", err.Error())
+		g.saveProblem = resumeProblem(err)
+		g.keepSave = !errors.Is(err, storage.ErrGameOver) // a finished game's save is already gone
+		g.showMenu()
+		return
+	}
+	g.keepSave = false
+	g.startGame(state)
+}
+
+// resumeProblem words a failure to resume the saved game for the player
+func resumeProblem(err error) string {
+	var version game.SaveVersionError
+	switch {
+	case errors.Is(err, storage.ErrGameOver):
+		return "The saved game had already ended"
+	case errors.As(err, &version) && version.Newer():
+		return "The saved game is from a newer version; it has been left as it is"
+	default:
+		return "The saved game couldn't be read; it has been left as it is"
+	}
+}
+
+// quit saves the game in progress and ends the app. A game that is won or lost isn't
+// saved, and any earlier save of it is removed, so Continue doesn't open a finished game.
+// A save that couldn't be resumed is left alone unless the player saved over it.
+func (g *Game) quit() error {
+	switch {
+	case g.play == nil:
+	case g.keepSave:
+		logger.Warn("Autosave: skipped, the save that couldn't be resumed is left in place")
+	case g.play.over():
+		path, err := storage.SavePath()
+		if err == nil {
+			err = storage.RemoveSave(path)
+		}
+		if err != nil {
+			logger.Error("Autosave: error: %s", err.Error())
+		}
+	default:
+		if err := g.play.saveGame(); err != nil {
+			logger.Error("Autosave: error: %s", err.Error())
+		}
+	}
+	return ebiten.Termination
+}
+
+// loadSettings applies the saved preferences; problems are logged and defaults kept
+func (g *Game) loadSettings() {
+	path, err := storage.SettingsPath()
+	if err == nil {
+		g.settings, err = storage.LoadSettings(path)
+	}
+	if err != nil {
+		logger.Warn("Settings: using defaults: %s", err.Error())
+		return
+	}
+	if g.settings.CardBack != "" {
+		if err := g.atlas.SetBack(g.settings.CardBack); err != nil {
+			logger.Warn("Settings: %s", err.Error())
+		}
+	}
+}
+
+// setCardBack switches the card back and remembers the choice
+func (g *Game) setCardBack(name string) error {
+	if err := g.atlas.SetBack(name); err != nil {
+		return err
+	}
+	g.settings.CardBack = name
+	path, err := storage.SettingsPath()
+	if err != nil {
+		return err
+	}
+	return storage.SaveSettings(path, g.settings)
+}
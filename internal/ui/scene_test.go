@@ -0,0 +1,17 @@
+package ui
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/staylor11x/spider-solitaire/internal/game"
+	"github.com/staylor11x/spider-solitaire/internal/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResumeProblem(t *testing.T) {
+	assert.Equal(t, "The saved game had already ended", resumeProblem(fmt.Errorf("load: %w", storage.ErrGameOver)))
+	assert.Contains(t, resumeProblem(game.SaveVersionError{Version: game.SaveVersion + 1}), "newer version")
+	assert.Equal(t, "The saved game couldn't be read; it has been left as it is", resumeProblem(game.SaveVersionError{Version: 0}))
+	assert.Equal(t, "The saved game couldn't be read; it has been left as it is", resumeProblem(game.ErrInvalidSave))
+}
@@ -2,6 +2,7 @@ package ui
 
 import (
 	"image/color"
+	"time"
 
 	"github.com/hajimehoshi/ebiten/v2/text/v2"
 	"golang.org/x/image/font/basicfont"
@@ -23,28 +24,67 @@ type Layout struct {
 	SelectionLiftPx      int
 	SelectionBorderPx    int
 	PlaceholderBorderPx  int
+	HintBorderPx         int
+	FocusBorderPx        int
+	StockX               int
+	StockY               int
+	MenuTitleY           int
+	MenuStartY           int
+	MenuItemWidth        int
+	MenuItemHeight       int
+	MenuItemGap          int
+	FoundationStep       int // distance between completed-run slots when there is room
+	FoundationGap        int // minimum space between the foundation and the stock
+	CalendarCell         int // side of one day in the daily challenge calendar
+	CalendarGap          int // space between calendar days
 }
 
 type Colors struct {
-	Background        color.RGBA
-	CardFaceUp        color.RGBA
-	CardFaceDown      color.RGBA
-	CardText          color.RGBA
-	SelectionOverlay  color.RGBA
-	SelectionBorder   color.RGBA
-	HoverOverlay      color.RGBA
-	ErrorPillBG       color.RGBA
-	ErrorPillText     color.RGBA
-	HelpOverlayBG     color.RGBA
-	HelpOverlayText   color.RGBA
-	PlaceholderBG     color.RGBA
-	PlaceholderBorder color.RGBA
+	Background          color.RGBA
+	CardFaceUp          color.RGBA
+	CardFaceDown        color.RGBA
+	CardText            color.RGBA
+	SelectionOverlay    color.RGBA
+	SelectionBorder     color.RGBA
+	HoverOverlay        color.RGBA
+	ErrorPillBG         color.RGBA
+	ErrorPillText       color.RGBA
+	HelpOverlayBG       color.RGBA
+	HelpOverlayText     color.RGBA
+	PlaceholderBG       color.RGBA
+	PlaceholderBorder   color.RGBA
+	HintSource          color.RGBA
+	HintSourceOverlay   color.RGBA
+	HintDestination     color.RGBA
+	DropTarget          color.RGBA
+	DropTargetOverlay   color.RGBA
+	MenuItem            color.RGBA
+	MenuItemActive      color.RGBA
+	MenuItemText        color.RGBA
+	MenuItemDisabled    color.RGBA
+	FoundationHighlight color.RGBA
+	FocusBorder         color.RGBA
+	CalendarDay         color.RGBA
+	CalendarPlayed      color.RGBA
+	CalendarWon         color.RGBA
+}
+
+// Timing sets how long card animations take; zero durations turn them off
+type Timing struct {
+	Move        time.Duration // a sequence sliding between piles
+	Deal        time.Duration // one dealt card travelling from the stock
+	DealStagger time.Duration // delay between the cards of a dealt row
+	Flip        time.Duration // a revealed card turning over
+	Run         time.Duration // one card of a completed run flying to the foundation
+	RunStagger  time.Duration // delay between the cards of a completed run
+	Arrival     time.Duration // highlight on a run that has reached the foundation
 }
 
 // Theme combines layout and color definition
 type Theme struct {
 	Layout Layout
 	Colors Colors
+	Timing Timing
 	Font   *text.GoXFace
 }
 
@@ -65,21 +105,57 @@ var DefaultTheme = Theme{
 		SelectionLiftPx:      5,
 		SelectionBorderPx:    2,
 		PlaceholderBorderPx:  2,
+		HintBorderPx:         3,
+		FocusBorderPx:        2,
+		StockX:               1120, // bottom-right corner
+		StockY:               580,
+		MenuTitleY:           120,
+		MenuStartY:           220,
+		MenuItemWidth:        320,
+		MenuItemHeight:       44,
+		MenuItemGap:          12,
+		FoundationStep:       30,
+		FoundationGap:        40,
+		CalendarCell:         40,
+		CalendarGap:          4,
 	},
 	Colors: Colors{
-		Background:        color.RGBA{R: 0, G: 100, B: 0, A: 255},
-		CardFaceUp:        color.RGBA{R: 255, G: 255, B: 255, A: 255},
-		CardFaceDown:      color.RGBA{R: 0, G: 0, B: 139, A: 255},
-		CardText:          color.RGBA{R: 0, G: 0, B: 0, A: 255},
-		SelectionOverlay:  color.RGBA{R: 0, G: 0, B: 0, A: 100},
-		SelectionBorder:   color.RGBA{R: 0, G: 0, B: 0, A: 255},
-		HoverOverlay:      color.RGBA{R: 0, G: 0, B: 0, A: 50},
-		ErrorPillBG:       color.RGBA{R: 80, G: 70, B: 90, A: 180},
-		ErrorPillText:     color.RGBA{R: 230, G: 230, B: 240, A: 255},
-		HelpOverlayBG:     color.RGBA{R: 0, G: 0, B: 0, A: 200},
-		HelpOverlayText:   color.RGBA{R: 255, G: 255, B: 255, A: 255},
-		PlaceholderBG:     color.RGBA{R: 0, G: 100, B: 0, A: 255},
-		PlaceholderBorder: color.RGBA{R: 255, G: 255, B: 255, A: 50},
+		Background:          color.RGBA{R: 0, G: 100, B: 0, A: 255},
+		CardFaceUp:          color.RGBA{R: 255, G: 255, B: 255, A: 255},
+		CardFaceDown:        color.RGBA{R: 0, G: 0, B: 139, A: 255},
+		CardText:            color.RGBA{R: 0, G: 0, B: 0, A: 255},
+		SelectionOverlay:    color.RGBA{R: 0, G: 0, B: 0, A: 100},
+		SelectionBorder:     color.RGBA{R: 0, G: 0, B: 0, A: 255},
+		HoverOverlay:        color.RGBA{R: 0, G: 0, B: 0, A: 50},
+		ErrorPillBG:         color.RGBA{R: 80, G: 70, B: 90, A: 180},
+		ErrorPillText:       color.RGBA{R: 230, G: 230, B: 240, A: 255},
+		HelpOverlayBG:       color.RGBA{R: 0, G: 0, B: 0, A: 200},
+		HelpOverlayText:     color.RGBA{R: 255, G: 255, B: 255, A: 255},
+		PlaceholderBG:       color.RGBA{R: 0, G: 100, B: 0, A: 255},
+		PlaceholderBorder:   color.RGBA{R: 255, G: 255, B: 255, A: 50},
+		HintSource:          color.RGBA{R: 255, G: 215, B: 0, A: 255},
+		HintSourceOverlay:   color.RGBA{R: 255, G: 215, B: 0, A: 40},
+		HintDestination:     color.RGBA{R: 80, G: 220, B: 255, A: 255},
+		DropTarget:          color.RGBA{R: 120, G: 255, B: 120, A: 255},
+		DropTargetOverlay:   color.RGBA{R: 120, G: 255, B: 120, A: 40},
+		MenuItem:            color.RGBA{R: 0, G: 0, B: 0, A: 90},
+		MenuItemActive:      color.RGBA{R: 255, G: 215, B: 0, A: 110},
+		MenuItemText:        color.RGBA{R: 255, G: 255, B: 255, A: 255},
+		MenuItemDisabled:    color.RGBA{R: 255, G: 255, B: 255, A: 90},
+		FoundationHighlight: color.RGBA{R: 255, G: 215, B: 0, A: 255},
+		FocusBorder:         color.RGBA{R: 255, G: 255, B: 255, A: 230},
+		CalendarDay:         color.RGBA{R: 0, G: 0, B: 0, A: 60},
+		CalendarPlayed:      color.RGBA{R: 255, G: 255, B: 255, A: 60},
+		CalendarWon:         color.RGBA{R: 255, G: 215, B: 0, A: 160},
+	},
+	Timing: Timing{
+		Move:        180 * time.Millisecond,
+		Deal:        220 * time.Millisecond,
+		DealStagger: 40 * time.Millisecond,
+		Flip:        160 * time.Millisecond,
+		Run:         350 * time.Millisecond,
+		RunStagger:  30 * time.Millisecond,
+		Arrival:     800 * time.Millisecond,
 	},
 	Font: text.NewGoXFace(basicfont.Face7x13),
 }